@@ -0,0 +1,129 @@
+package analysis
+
+import (
+	"GridPlay/engine"
+	"GridPlay/gameRules"
+	"cmp"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+type Result string
+const (
+	Win Result = "win"
+	Draw Result = "draw"
+	Loss Result = "loss"
+	// Position was too big to solve, see MoveValue.Score.
+	Unknown Result = "unknown"
+)
+
+// MoveValue is value of a legal move for the player to move.
+type MoveValue struct {
+	Move gameRules.Move `json:"move"`
+	Result Result `json:"result"`
+	// Moves of both players until the result, including this one. Only for solved positions.
+	Distance int `json:"distance,omitempty"`
+	// Expected score from 0 (loss) to 1 (win). Only for unsolved positions.
+	Score float64 `json:"score,omitempty"`
+}
+
+type Analysis struct {
+	Solved bool `json:"solved"`
+	Moves []MoveValue `json:"moves"`
+}
+
+type Config struct {
+	// Node limit for solving the position.
+	MaxNodes int
+	// MCTS iterations used when the position cannot be solved.
+	Iterations int
+	// Time limit of the whole analysis, the first half is given to the solver and the rest to MCTS,
+	// whichever of Iterations and Duration ends first. 0 means no limit.
+	Duration time.Duration
+	Seed int64
+}
+
+func DefaultConfig() Config {
+	return Config{
+		MaxNodes: 1000000,
+		Iterations: 20000,
+		Duration: 2 * time.Second,
+		Seed: 1,
+	}
+}
+
+// Analyze values all legal moves of the current player, the best move first.
+// Small games are solved exactly, bigger ones are estimated by MCTS.
+func Analyze(state gameRules.Searchable, config Config) Analysis {
+	start := time.Now()
+
+	solver := engine.CreateAlphaBeta(0, config.MaxNodes, rand.New(rand.NewSource(config.Seed)))
+	if config.Duration > 0 {
+		solver.Deadline = start.Add(config.Duration / 2)
+	}
+
+	evaluations, solved := solver.Solve(state)
+
+	if solved {
+		return Analysis{
+			Solved: true,
+			Moves: fromEvaluations(evaluations),
+		}
+	}
+
+	duration := config.Duration
+	if duration > 0 {
+		// Solver stops a little after its deadline, MCTS still gets some time.
+		duration = max(config.Duration - time.Since(start), config.Duration / 4)
+	}
+
+	mcts := engine.CreateMCTS(engine.MCTSConfig{
+		Iterations: config.Iterations,
+		Duration: duration,
+		Seed: config.Seed,
+	})
+
+	return Analysis{
+		Solved: false,
+		Moves: fromStats(mcts.Analyze(state)),
+	}
+}
+
+func fromEvaluations(evaluations []engine.Evaluation) []MoveValue {
+	slices.SortStableFunc(evaluations, func(a, b engine.Evaluation) int {
+		return cmp.Compare(b.Value, a.Value)
+	})
+
+	moves := make([]MoveValue, 0, len(evaluations))
+	for _, evaluation := range evaluations {
+		moves = append(moves, fromValue(evaluation.Move, evaluation.Value))
+	}
+
+	return moves
+}
+
+func fromValue(move gameRules.Move, value int) MoveValue {
+	switch {
+	case value > 0:
+		return MoveValue{Move: move, Result: Win, Distance: engine.WinValue - value}
+	case value < 0:
+		return MoveValue{Move: move, Result: Loss, Distance: engine.WinValue + value}
+	default:
+		return MoveValue{Move: move, Result: Draw}
+	}
+}
+
+func fromStats(stats []engine.MoveStats) []MoveValue {
+	moves := make([]MoveValue, 0, len(stats))
+
+	for _, stat := range stats {
+		moves = append(moves, MoveValue{
+			Move: stat.Move,
+			Result: Unknown,
+			Score: stat.Value,
+		})
+	}
+
+	return moves
+}
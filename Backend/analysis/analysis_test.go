@@ -0,0 +1,109 @@
+package analysis
+
+import (
+	"GridPlay/game"
+	"GridPlay/gameRules"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func load(t *testing.T, create gameRules.Factory, position string) gameRules.Searchable {
+	rules, err := create(nil)
+	require.NoError(t, err)
+	require.NoError(t, rules.(gameRules.PositionLoader).LoadPosition(position))
+
+	return rules.(gameRules.Searchable)
+}
+
+func TestAnalyzeSolvedPosition(t *testing.T) {
+	// x to move, wins at once with (2,2) and in three with (2,0).
+	state := load(t, game.CreateRules, "x.o/.x./o..")
+
+	result := Analyze(state, DefaultConfig())
+	require.True(t, result.Solved)
+	require.Len(t, result.Moves, 5)
+
+	best := result.Moves[0]
+	require.Equal(t, game.Pos{X: 2, Y: 2}, best.Move)
+	require.Equal(t, Win, best.Result)
+	require.Equal(t, 1, best.Distance)
+
+	for _, move := range result.Moves {
+		require.NotEqual(t, Unknown, move.Result)
+	}
+}
+
+func TestAnalyzeEmptyBoardIsDraw(t *testing.T) {
+	state := load(t, game.CreateRules, ".../.../...")
+
+	result := Analyze(state, DefaultConfig())
+	require.True(t, result.Solved)
+	require.Len(t, result.Moves, 9)
+
+	for _, move := range result.Moves {
+		require.Equal(t, Draw, move.Result)
+	}
+}
+
+func TestAnalyzeLosingMoves(t *testing.T) {
+	// o to move must block at (2,2), everything else loses.
+	state := load(t, game.CreateRules, "x../.x./o..")
+
+	result := Analyze(state, DefaultConfig())
+	require.True(t, result.Solved)
+	require.Equal(t, game.Pos{X: 2, Y: 2}, result.Moves[0].Move)
+	require.Equal(t, Draw, result.Moves[0].Result)
+
+	last := result.Moves[len(result.Moves) - 1]
+	require.Equal(t, Loss, last.Result)
+	require.Equal(t, 2, last.Distance)
+}
+
+func TestAnalyzeUnsolvedPosition(t *testing.T) {
+	state := load(t, game.CreateGomokuRules, "......./......./......./...x.../......./......./.......")
+
+	config := DefaultConfig()
+	config.MaxNodes = 1000
+	config.Iterations = 500
+
+	result := Analyze(state, config)
+	require.False(t, result.Solved)
+	require.Len(t, result.Moves, 48)
+	require.Equal(t, Unknown, result.Moves[0].Result)
+}
+
+func TestAnalyzeStopsAtDuration(t *testing.T) {
+	state := load(t, game.CreateGomokuRules, "......./......./......./...x.../......./......./.......")
+
+	config := DefaultConfig()
+	config.MaxNodes = 1000
+	config.Iterations = 0
+	config.Duration = 50 * time.Millisecond
+
+	start := time.Now()
+	result := Analyze(state, config)
+
+	require.False(t, result.Solved)
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestAnalyzeSolverStopsAtDuration(t *testing.T) {
+	rows := make([]string, 15)
+	for i := range rows {
+		rows[i] = strings.Repeat(".", 15)
+	}
+	state := load(t, game.CreateGomokuRules, strings.Join(rows, "/"))
+
+	config := DefaultConfig()
+	config.Duration = 200 * time.Millisecond
+
+	start := time.Now()
+	result := Analyze(state, config)
+
+	require.False(t, result.Solved)
+	require.NotEmpty(t, result.Moves)
+	require.Less(t, time.Since(start), time.Second)
+}
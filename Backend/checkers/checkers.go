@@ -0,0 +1,376 @@
+package checkers
+
+import (
+	"GridPlay/assert"
+	"GridPlay/game"
+	"GridPlay/game/winState"
+	"GridPlay/gameRules"
+	"encoding/json"
+	"errors"
+	"slices"
+)
+
+const (
+	Name = "checkers"
+	Size = 8
+	// Moves of both players without capture after which the game is drawn.
+	DrawMoveLimit = 80
+)
+
+const empty = -1
+
+// Men and kings of both players.
+var symbols = [2][2]rune{{'b', 'B'}, {'w', 'W'}}
+
+// Move is a path of the piece: starting square and every square it lands on.
+type Move struct {
+	Path []game.Pos `json:"path"`
+}
+
+type MoveResult struct {
+	Path []game.Pos `json:"path"`
+	Captured []game.Pos `json:"captured"`
+	Promoted bool `json:"promoted"`
+}
+
+type State struct {
+	// Board[x][y], men are b and w, kings B and W, ' ' is an empty square.
+	Board [][]rune `json:"board"`
+	CurrentPlayer int `json:"currentPlayer"`
+}
+
+type piece struct {
+	player int
+	king bool
+}
+
+// Rules of English draughts. Player 0 starts at rows 0-2 and moves up the board.
+// Captures are mandatory and a capturing piece must continue jumping while it can.
+type Rules struct {
+	cells [Size][Size]piece
+	currentPlayer int
+	movesWithoutCapture int
+	moveNumber int
+	winState winState.WinState
+}
+
+func CreateRules(params gameRules.Params) (gameRules.GameRules, error) {
+	rules := &Rules{
+		currentPlayer: 0,
+		winState: winState.Values.None,
+	}
+
+	for x := range Size {
+		for y := range Size {
+			rules.cells[x][y] = piece{player: empty}
+
+			if !isDark(game.Pos{X: x, Y: y}) {
+				continue
+			}
+
+			if y < 3 {
+				rules.cells[x][y] = piece{player: 0}
+			} else if y >= Size - 3 {
+				rules.cells[x][y] = piece{player: 1}
+			}
+		}
+	}
+
+	return rules, nil
+}
+
+func isDark(pos game.Pos) bool {
+	return (pos.X + pos.Y) % 2 == 1
+}
+
+func inBounds(pos game.Pos) bool {
+	return pos.X >= 0 && pos.Y >= 0 && pos.X < Size && pos.Y < Size
+}
+
+func (rules *Rules) GetName() string {
+	return Name
+}
+
+func (rules *Rules) DecodeMove(data []byte) (gameRules.Move, error) {
+	var move Move
+	err := json.Unmarshal(data, &move)
+
+	if err != nil {
+		return nil, errors.New("move must be a path of positions")
+	}
+
+	return move, nil
+}
+
+func (rules *Rules) ValidateMove(playerId int, move gameRules.Move) error {
+	m, ok := move.(Move)
+	assert.Assert(ok, "type assertion failed for checkers move")
+
+	if rules.winState != winState.Values.None {
+		return errors.New("cannot move after game ended")
+	}
+
+	if rules.currentPlayer != playerId {
+		return errors.New("not your round")
+	}
+
+	if len(m.Path) < 2 {
+		return errors.New("path must contain at least two positions")
+	}
+
+	for _, pos := range m.Path {
+		if !inBounds(pos) {
+			return errors.New("position is out of range")
+		}
+	}
+
+	start := m.Path[0]
+	if rules.cells[start.X][start.Y].player != playerId {
+		return errors.New("path must start at your piece")
+	}
+
+	legal := rules.GetLegalPaths(playerId)
+	for _, path := range legal {
+		if slices.Equal(path, m.Path) {
+			return nil
+		}
+	}
+
+	if isCapturePath(legal) {
+		return errors.New("capture is mandatory and must be completed")
+	}
+
+	return errors.New("illegal move")
+}
+
+func (rules *Rules) ApplyMove(playerId int, move gameRules.Move) (gameRules.MoveResult, error) {
+	err := rules.ValidateMove(playerId, move)
+
+	if err != nil {
+		return nil, err
+	}
+
+	path := move.(Move).Path
+	start := path[0]
+	end := path[len(path) - 1]
+	p := rules.cells[start.X][start.Y]
+	captured := []game.Pos{}
+
+	for i := 1; i < len(path); i++ {
+		from, to := path[i - 1], path[i]
+
+		if abs(to.X - from.X) == 2 {
+			mid := game.Pos{X: (from.X + to.X) / 2, Y: (from.Y + to.Y) / 2}
+			rules.cells[mid.X][mid.Y] = piece{player: empty}
+			captured = append(captured, mid)
+		}
+	}
+
+	promoted := !p.king && end.Y == promotionRow(playerId)
+	p.king = p.king || promoted
+
+	rules.cells[start.X][start.Y] = piece{player: empty}
+	rules.cells[end.X][end.Y] = p
+
+	if len(captured) > 0 || promoted {
+		rules.movesWithoutCapture = 0
+	} else {
+		rules.movesWithoutCapture++
+	}
+
+	rules.moveNumber++
+	rules.currentPlayer = 1 - playerId
+	rules.winState = rules.checkOutcome()
+
+	return MoveResult{
+		Path: path,
+		Captured: captured,
+		Promoted: promoted,
+	}, nil
+}
+
+// checkOutcome is called after the turn passed. Player who cannot move loses.
+func (rules *Rules) checkOutcome() winState.WinState {
+	if len(rules.GetLegalPaths(rules.currentPlayer)) == 0 {
+		winner := 1 - rules.currentPlayer
+
+		return winState.CreateWin(winState.Player{
+			Id: winner,
+			Char: int(symbols[winner][0]),
+		})
+	}
+
+	if rules.movesWithoutCapture >= DrawMoveLimit {
+		return winState.Values.Draw
+	}
+
+	return winState.Values.None
+}
+
+func promotionRow(playerId int) int {
+	if playerId == 0 {
+		return Size - 1
+	}
+	return 0
+}
+
+func (p piece) directions() []game.Pos {
+	forward := 1
+	if p.player == 1 {
+		forward = -1
+	}
+
+	dirs := []game.Pos{{X: 1, Y: forward}, {X: -1, Y: forward}}
+	if p.king {
+		dirs = append(dirs, game.Pos{X: 1, Y: -forward}, game.Pos{X: -1, Y: -forward})
+	}
+
+	return dirs
+}
+
+// GetLegalPaths returns all legal moves of the player. If any capture
+// is possible, only complete capture sequences are returned.
+func (rules *Rules) GetLegalPaths(playerId int) [][]game.Pos {
+	captures := [][]game.Pos{}
+	steps := [][]game.Pos{}
+
+	for x := range Size {
+		for y := range Size {
+			p := rules.cells[x][y]
+			if p.player != playerId {
+				continue
+			}
+
+			start := game.Pos{X: x, Y: y}
+			captures = append(captures, rules.jumps(start, p, []game.Pos{start}, nil)...)
+
+			for _, dir := range p.directions() {
+				to := game.Pos{X: x + dir.X, Y: y + dir.Y}
+
+				if inBounds(to) && rules.cells[to.X][to.Y].player == empty {
+					steps = append(steps, []game.Pos{start, to})
+				}
+			}
+		}
+	}
+
+	if len(captures) > 0 {
+		return captures
+	}
+
+	return steps
+}
+
+// jumps returns complete capture sequences continuing path. The moving piece
+// is still on its starting square, so that square is treated as empty.
+func (rules *Rules) jumps(from game.Pos, p piece, path []game.Pos, captured []game.Pos) [][]game.Pos {
+	result := [][]game.Pos{}
+	start := path[0]
+
+	for _, dir := range p.directions() {
+		mid := game.Pos{X: from.X + dir.X, Y: from.Y + dir.Y}
+		to := game.Pos{X: from.X + 2 * dir.X, Y: from.Y + 2 * dir.Y}
+
+		if !inBounds(to) || rules.cells[mid.X][mid.Y].player != 1 - p.player || slices.Contains(captured, mid) {
+			continue
+		}
+
+		if rules.cells[to.X][to.Y].player != empty && to != start {
+			continue
+		}
+
+		nextPath := append(slices.Clone(path), to)
+		nextCaptured := append(slices.Clone(captured), mid)
+
+		// Man reaching the last row is promoted and the move ends.
+		if !p.king && to.Y == promotionRow(p.player) {
+			result = append(result, nextPath)
+			continue
+		}
+
+		further := rules.jumps(to, p, nextPath, nextCaptured)
+		if len(further) == 0 {
+			result = append(result, nextPath)
+		} else {
+			result = append(result, further...)
+		}
+	}
+
+	return result
+}
+
+func isCapturePath(paths [][]game.Pos) bool {
+	return len(paths) > 0 && abs(paths[0][1].X - paths[0][0].X) == 2
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func (rules *Rules) GetCurrentPlayer() int {
+	return rules.currentPlayer
+}
+
+func (rules *Rules) GetOutcome() winState.WinState {
+	return rules.winState
+}
+
+func (rules *Rules) GetState() any {
+	board := make([][]rune, Size)
+
+	for x := range Size {
+		board[x] = make([]rune, Size)
+
+		for y := range Size {
+			board[x][y] = ' '
+			if p := rules.cells[x][y]; p.player != empty {
+				board[x][y] = p.symbol()
+			}
+		}
+	}
+
+	return State{
+		Board: board,
+		CurrentPlayer: rules.currentPlayer,
+	}
+}
+
+func (p piece) symbol() rune {
+	if p.king {
+		return symbols[p.player][1]
+	}
+	return symbols[p.player][0]
+}
+
+func (rules *Rules) GetPlayerSymbol(playerId int) rune {
+	assert.Assert(playerId == 0 || playerId == 1, "player id must be 0 or 1", "player id", playerId)
+
+	return symbols[playerId][0]
+}
+
+func (rules *Rules) GetMoveNumber() int {
+	return rules.moveNumber
+}
+
+func (rules *Rules) Clone() gameRules.Searchable {
+	clone := *rules
+
+	return &clone
+}
+
+func (rules *Rules) GetLegalMoves() []gameRules.Move {
+	moves := []gameRules.Move{}
+
+	if rules.winState != winState.Values.None {
+		return moves
+	}
+
+	for _, path := range rules.GetLegalPaths(rules.currentPlayer) {
+		moves = append(moves, Move{Path: path})
+	}
+
+	return moves
+}
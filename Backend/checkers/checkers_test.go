@@ -0,0 +1,111 @@
+package checkers
+
+import (
+	"GridPlay/game"
+	"GridPlay/game/winState"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createEmptyRules(t *testing.T) *Rules {
+	r, err := CreateRules(nil)
+	require.NoError(t, err)
+
+	rules := r.(*Rules)
+	for x := range Size {
+		for y := range Size {
+			rules.cells[x][y] = piece{player: empty}
+		}
+	}
+
+	return rules
+}
+
+func path(positions ...game.Pos) Move {
+	return Move{Path: positions}
+}
+
+func TestCheckersOpening(t *testing.T) {
+	r, err := CreateRules(nil)
+	require.NoError(t, err)
+	rules := r.(*Rules)
+
+	require.Len(t, rules.GetLegalPaths(0), 7)
+
+	move, err := rules.DecodeMove([]byte(`{"path":[{"x":1,"y":2},{"x":2,"y":3}]}`))
+	require.NoError(t, err)
+	require.NoError(t, rules.ValidateMove(0, move))
+
+	require.Error(t, rules.ValidateMove(1, move))
+	require.Error(t, rules.ValidateMove(0, path(game.Pos{X: 1, Y: 2})))
+	require.Error(t, rules.ValidateMove(0, path(game.Pos{X: 1, Y: 2}, game.Pos{X: 1, Y: 3})))
+	require.Error(t, rules.ValidateMove(0, path(game.Pos{X: 0, Y: 1}, game.Pos{X: 1, Y: 2})))
+
+	_, err = rules.ApplyMove(0, move)
+	require.NoError(t, err)
+	require.Equal(t, 1, rules.GetCurrentPlayer())
+}
+
+func TestCheckersMandatoryCapture(t *testing.T) {
+	rules := createEmptyRules(t)
+	rules.cells[1][2] = piece{player: 0}
+	rules.cells[5][2] = piece{player: 0}
+	rules.cells[2][3] = piece{player: 1}
+	rules.cells[7][6] = piece{player: 1}
+
+	err := rules.ValidateMove(0, path(game.Pos{X: 5, Y: 2}, game.Pos{X: 6, Y: 3}))
+	require.ErrorContains(t, err, "mandatory")
+
+	result, err := rules.ApplyMove(0, path(game.Pos{X: 1, Y: 2}, game.Pos{X: 3, Y: 4}))
+	require.NoError(t, err)
+	require.Equal(t, []game.Pos{{X: 2, Y: 3}}, result.(MoveResult).Captured)
+	require.Equal(t, empty, rules.cells[2][3].player)
+}
+
+func TestCheckersMultiJump(t *testing.T) {
+	rules := createEmptyRules(t)
+	rules.cells[0][1] = piece{player: 0}
+	rules.cells[1][2] = piece{player: 1}
+	rules.cells[3][4] = piece{player: 1}
+	rules.cells[7][6] = piece{player: 1}
+
+	partial := path(game.Pos{X: 0, Y: 1}, game.Pos{X: 2, Y: 3})
+	require.Error(t, rules.ValidateMove(0, partial))
+
+	full := path(game.Pos{X: 0, Y: 1}, game.Pos{X: 2, Y: 3}, game.Pos{X: 4, Y: 5})
+	result, err := rules.ApplyMove(0, full)
+	require.NoError(t, err)
+	require.Equal(t, []game.Pos{{X: 1, Y: 2}, {X: 3, Y: 4}}, result.(MoveResult).Captured)
+	require.Equal(t, winState.Values.None, rules.GetOutcome())
+}
+
+func TestCheckersPromotion(t *testing.T) {
+	rules := createEmptyRules(t)
+	rules.cells[1][6] = piece{player: 0}
+	rules.cells[7][6] = piece{player: 1}
+
+	result, err := rules.ApplyMove(0, path(game.Pos{X: 1, Y: 6}, game.Pos{X: 0, Y: 7}))
+	require.NoError(t, err)
+	require.True(t, result.(MoveResult).Promoted)
+	require.Equal(t, 'B', rules.GetState().(State).Board[0][7])
+
+	_, err = rules.ApplyMove(1, path(game.Pos{X: 7, Y: 6}, game.Pos{X: 6, Y: 5}))
+	require.NoError(t, err)
+
+	// King moves backwards.
+	require.NoError(t, rules.ValidateMove(0, path(game.Pos{X: 0, Y: 7}, game.Pos{X: 1, Y: 6})))
+}
+
+func TestCheckersWinByCapturingAll(t *testing.T) {
+	rules := createEmptyRules(t)
+	rules.cells[1][2] = piece{player: 0}
+	rules.cells[2][3] = piece{player: 1}
+
+	_, err := rules.ApplyMove(0, path(game.Pos{X: 1, Y: 2}, game.Pos{X: 3, Y: 4}))
+	require.NoError(t, err)
+
+	outcome := rules.GetOutcome()
+	require.True(t, winState.IsWin(outcome))
+	require.Equal(t, 0, outcome.GetPlayer().Id)
+}
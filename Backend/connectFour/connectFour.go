@@ -0,0 +1,219 @@
+package connectFour
+
+import (
+	"GridPlay/assert"
+	"GridPlay/game"
+	"GridPlay/game/winState"
+	"GridPlay/gameRules"
+	"encoding/json"
+	"errors"
+)
+
+const (
+	Name = "connectfour"
+	DefaultColumns = 7
+	DefaultRows = 6
+	WinLength = 4
+)
+
+const (
+	ParamColumns = "columns"
+	ParamRows = "rows"
+)
+
+// Move is sent by the client, the row is resolved by the server.
+type Move struct {
+	Column int `json:"column"`
+}
+
+type State struct {
+	// Board[column][row], row 0 is the bottom one.
+	Board [][]rune `json:"board"`
+	Columns int `json:"columns"`
+	Rows int `json:"rows"`
+	CurrentPlayer int `json:"currentPlayer"`
+}
+
+// Rules of Connect Four. Pieces fall to the lowest free row of a column,
+// four in a row wins, the rest is the same as in m,n,k-game.
+type Rules struct {
+	game *game.Game
+}
+
+func CreateRules(params gameRules.Params) (gameRules.GameRules, error) {
+	g, err := game.CreateCustomGame(
+		params.Get(ParamColumns, DefaultColumns),
+		params.Get(ParamRows, DefaultRows),
+		WinLength,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rules{
+		game: g,
+	}, nil
+}
+
+func (rules *Rules) GetName() string {
+	return Name
+}
+
+func (rules *Rules) GetParams() gameRules.Params {
+	assert.NotNil(rules.game, "game was nil")
+
+	return gameRules.Params{
+		ParamColumns: rules.game.GetWidth(),
+		ParamRows: rules.game.GetHeight(),
+	}
+}
+
+func (rules *Rules) DecodeMove(data []byte) (gameRules.Move, error) {
+	var move Move
+	err := json.Unmarshal(data, &move)
+
+	if err != nil {
+		return nil, errors.New("move must be a column")
+	}
+
+	return move, nil
+}
+
+func (rules *Rules) ValidateMove(playerId int, move gameRules.Move) error {
+	_, err := rules.resolve(playerId, move)
+
+	return err
+}
+
+// ApplyMove drops the piece and returns resolved position as game.Pos.
+func (rules *Rules) ApplyMove(playerId int, move gameRules.Move) (gameRules.MoveResult, error) {
+	pos, err := rules.resolve(playerId, move)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = rules.game.Move(pos)
+	if err != nil {
+		return nil, err
+	}
+
+	return pos, nil
+}
+
+func (rules *Rules) resolve(playerId int, move gameRules.Move) (game.Pos, error) {
+	assert.NotNil(rules.game, "game was nil")
+
+	m, ok := move.(Move)
+	assert.Assert(ok, "type assertion failed for connect four move")
+
+	if rules.GetCurrentPlayer() != playerId {
+		return game.Pos{}, errors.New("not your round")
+	}
+
+	pos, err := rules.dropPosition(m.Column)
+	if err != nil {
+		return game.Pos{}, err
+	}
+
+	return pos, rules.game.ValidateMove(pos)
+}
+
+func (rules *Rules) dropPosition(column int) (game.Pos, error) {
+	if column < 0 || column >= rules.game.GetWidth() {
+		return game.Pos{}, errors.New("column is out of range")
+	}
+
+	for row := range rules.game.GetHeight() {
+		pos := game.Pos{X: column, Y: row}
+
+		if rules.game.IsEmpty(pos) {
+			return pos, nil
+		}
+	}
+
+	return game.Pos{}, errors.New("column is full")
+}
+
+func (rules *Rules) GetCurrentPlayer() int {
+	assert.NotNil(rules.game, "game was nil")
+
+	player := rules.game.GetCurrentRoundPlayer()
+	return player.GetID()
+}
+
+func (rules *Rules) GetOutcome() winState.WinState {
+	assert.NotNil(rules.game, "game was nil")
+
+	return rules.game.GetWinState()
+}
+
+func (rules *Rules) GetState() any {
+	assert.NotNil(rules.game, "game was nil")
+
+	return State{
+		Board: rules.game.GetBoard(),
+		Columns: rules.game.GetWidth(),
+		Rows: rules.game.GetHeight(),
+		CurrentPlayer: rules.GetCurrentPlayer(),
+	}
+}
+
+func (rules *Rules) GetPlayerSymbol(playerId int) rune {
+	assert.NotNil(rules.game, "game was nil")
+
+	player := rules.game.GetPlayerWithId(playerId)
+	return player.GetChar().GetRune()
+}
+
+func (rules *Rules) SwapSymbols() {
+	assert.NotNil(rules.game, "game was nil")
+
+	rules.game.SwapChars()
+}
+
+func (rules *Rules) GetMoveNumber() int {
+	assert.NotNil(rules.game, "game was nil")
+
+	return rules.game.GetMoveNumber()
+}
+
+func (rules *Rules) Clone() gameRules.Searchable {
+	assert.NotNil(rules.game, "game was nil")
+
+	return &Rules{
+		game: rules.game.Clone(),
+	}
+}
+
+func (rules *Rules) GetLegalMoves() []gameRules.Move {
+	assert.NotNil(rules.game, "game was nil")
+
+	moves := []gameRules.Move{}
+
+	if rules.game.GetWinState() != winState.Values.None {
+		return moves
+	}
+
+	for column := range rules.game.GetWidth() {
+		if _, err := rules.dropPosition(column); err == nil {
+			moves = append(moves, Move{Column: column})
+		}
+	}
+
+	return moves
+}
+
+// UndoMove removes the last dropped piece, it is always on top of its column.
+func (rules *Rules) UndoMove() error {
+	assert.NotNil(rules.game, "game was nil")
+
+	return rules.game.Undo()
+}
+
+func (rules *Rules) GetMoveCount() int {
+	assert.NotNil(rules.game, "game was nil")
+
+	return rules.game.GetMoveCount()
+}
@@ -0,0 +1,73 @@
+package connectFour
+
+import (
+	"GridPlay/game"
+	"GridPlay/game/winState"
+	"GridPlay/gameRules"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func play(t *testing.T, rules gameRules.GameRules, column int) gameRules.MoveResult {
+	move, err := rules.DecodeMove([]byte(fmt.Sprintf(`{"column":%d}`, column)))
+	require.NoError(t, err)
+
+	result, err := rules.ApplyMove(rules.GetCurrentPlayer(), move)
+	require.NoError(t, err)
+
+	return result
+}
+
+func TestConnectFourGravity(t *testing.T) {
+	rules, err := CreateRules(nil)
+	require.NoError(t, err)
+
+	require.Equal(t, game.Pos{X: 3, Y: 0}, play(t, rules, 3))
+	require.Equal(t, game.Pos{X: 3, Y: 1}, play(t, rules, 3))
+	require.Equal(t, game.Pos{X: 2, Y: 0}, play(t, rules, 2))
+}
+
+func TestConnectFourFullColumn(t *testing.T) {
+	rules, err := CreateRules(gameRules.Params{ParamRows: 2})
+	require.NoError(t, err)
+
+	play(t, rules, 0)
+	play(t, rules, 0)
+
+	require.Error(t, rules.ValidateMove(rules.GetCurrentPlayer(), Move{Column: 0}))
+	require.Error(t, rules.ValidateMove(rules.GetCurrentPlayer(), Move{Column: 7}))
+	require.Error(t, rules.ValidateMove(1 - rules.GetCurrentPlayer(), Move{Column: 1}))
+}
+
+func TestConnectFourVerticalWin(t *testing.T) {
+	rules, err := CreateRules(nil)
+	require.NoError(t, err)
+
+	for range 3 {
+		play(t, rules, 0)
+		play(t, rules, 1)
+	}
+	require.Equal(t, winState.Values.None, rules.GetOutcome())
+
+	play(t, rules, 0)
+	require.True(t, winState.IsWin(rules.GetOutcome()))
+	require.Equal(t, 0, rules.GetOutcome().GetPlayer().Id)
+}
+
+func TestConnectFourUndo(t *testing.T) {
+	rules, err := CreateRules(nil)
+	require.NoError(t, err)
+
+	play(t, rules, 3)
+	play(t, rules, 3)
+
+	undoable := rules.(gameRules.Undoable)
+	require.Equal(t, 2, undoable.GetMoveCount())
+	require.NoError(t, undoable.UndoMove())
+	require.Equal(t, 1, rules.GetCurrentPlayer())
+
+	// Piece falls to the freed cell again.
+	require.Equal(t, game.Pos{X: 3, Y: 1}, play(t, rules, 3))
+}
@@ -0,0 +1,186 @@
+package engine
+
+import (
+	"GridPlay/game/winState"
+	"GridPlay/gameRules"
+	"math/rand"
+	"time"
+)
+
+const (
+	DefaultMaxDepth = 9
+	DefaultMaxNodes = 200000
+	// Value of a win, reduced by its distance so faster wins are preferred.
+	WinValue = 1000
+	unlimitedDepth = WinValue
+	// Nodes searched between checks of the deadline.
+	deadlineCheckNodes = 1024
+)
+
+// Evaluation is exact value of a move for the player making it: WinValue - n for a win
+// n moves from now, -WinValue + n for a loss, 0 for a draw.
+type Evaluation struct {
+	Move gameRules.Move
+	Value int
+}
+
+// AlphaBetaEngine is minimax with alpha-beta pruning and iterative deepening.
+// It plays perfectly when the whole game tree fits into MaxDepth and MaxNodes,
+// on bigger games positions at the depth limit are valued as draws.
+type AlphaBetaEngine struct {
+	MaxDepth int
+	MaxNodes int
+	// Search is not complete after Deadline, zero means no deadline.
+	Deadline time.Time
+	rng *rand.Rand
+	nodes int
+}
+
+func CreateAlphaBeta(maxDepth, maxNodes int, rng *rand.Rand) *AlphaBetaEngine {
+	return &AlphaBetaEngine{
+		MaxDepth: maxDepth,
+		MaxNodes: maxNodes,
+		rng: rng,
+	}
+}
+
+func (engine *AlphaBetaEngine) ChooseMove(state gameRules.Searchable) gameRules.Move {
+	moves := state.GetLegalMoves()
+
+	if len(moves) == 0 {
+		return nil
+	}
+
+	// Equal moves are played in random order.
+	engine.rng.Shuffle(len(moves), func(i, j int) {
+		moves[i], moves[j] = moves[j], moves[i]
+	})
+
+	best := moves[0]
+
+	for depth := 1; depth <= engine.MaxDepth; depth++ {
+		engine.nodes = 0
+		move, value, complete := engine.searchRoot(state, moves, depth)
+
+		if !complete {
+			break
+		}
+
+		best = move
+
+		if value >= WinValue - engine.MaxDepth || value <= -WinValue + engine.MaxDepth {
+			break
+		}
+	}
+
+	return best
+}
+
+// Solve evaluates every legal move, searching until the end of the game.
+// Returns false when the game tree does not fit into MaxNodes or the search did not end before Deadline.
+func (engine *AlphaBetaEngine) Solve(state gameRules.Searchable) ([]Evaluation, bool) {
+	engine.nodes = 0
+	playerId := state.GetCurrentPlayer()
+	evaluations := []Evaluation{}
+
+	for _, move := range state.GetLegalMoves() {
+		value, complete := engine.search(play(state, move), playerId, unlimitedDepth, 1, -WinValue - 1, WinValue + 1)
+
+		if !complete {
+			return nil, false
+		}
+
+		evaluations = append(evaluations, Evaluation{
+			Move: move,
+			Value: value,
+		})
+	}
+
+	return evaluations, true
+}
+
+func (engine *AlphaBetaEngine) searchRoot(state gameRules.Searchable, moves []gameRules.Move, depth int) (gameRules.Move, int, bool) {
+	playerId := state.GetCurrentPlayer()
+	best := moves[0]
+	bestValue := -WinValue - 1
+
+	for _, move := range moves {
+		value, complete := engine.search(play(state, move), playerId, depth - 1, 1, bestValue, WinValue + 1)
+
+		if !complete {
+			return best, bestValue, false
+		}
+
+		if value > bestValue {
+			best, bestValue = move, value
+		}
+	}
+
+	return best, bestValue, true
+}
+
+// search returns value of the state for playerId.
+// Turns do not always alternate, e.g. in reversi, so it is minimax rather than negamax.
+func (engine *AlphaBetaEngine) search(state gameRules.Searchable, playerId, depth, ply, alpha, beta int) (int, bool) {
+	if value, ended := terminalValue(state, playerId, ply); ended {
+		return value, true
+	}
+
+	if depth == 0 {
+		return 0, true
+	}
+
+	engine.nodes++
+	if engine.nodes > engine.MaxNodes || engine.isPastDeadline() {
+		return 0, false
+	}
+
+	maximizing := state.GetCurrentPlayer() == playerId
+
+	for _, move := range state.GetLegalMoves() {
+		value, complete := engine.search(play(state, move), playerId, depth - 1, ply + 1, alpha, beta)
+
+		if !complete {
+			return 0, false
+		}
+
+		if maximizing && value > alpha {
+			alpha = value
+		} else if !maximizing && value < beta {
+			beta = value
+		}
+
+		if alpha >= beta {
+			break
+		}
+	}
+
+	if maximizing {
+		return alpha, true
+	}
+	return beta, true
+}
+
+// isPastDeadline checks the clock only every deadlineCheckNodes nodes, reading it is not free.
+func (engine *AlphaBetaEngine) isPastDeadline() bool {
+	if engine.Deadline.IsZero() || engine.nodes % deadlineCheckNodes != 0 {
+		return false
+	}
+
+	return time.Now().After(engine.Deadline)
+}
+
+func terminalValue(state gameRules.Searchable, playerId, ply int) (int, bool) {
+	outcome := state.GetOutcome()
+
+	switch {
+	case outcome == winState.Values.None:
+		return 0, false
+	case winState.IsWin(outcome) && outcome.GetPlayer().Id == playerId:
+		return WinValue - ply, true
+	case winState.IsWin(outcome):
+		return -WinValue + ply, true
+	default:
+		return 0, true
+	}
+}
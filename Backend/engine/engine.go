@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"GridPlay/assert"
+	"GridPlay/game/winState"
+	"GridPlay/gameRules"
+	"errors"
+	"math/rand"
+)
+
+type Engine interface {
+	// ChooseMove returns move for the current player, nil if there are no legal moves.
+	ChooseMove(state gameRules.Searchable) gameRules.Move
+}
+
+// Factory creates a new engine, engines are not safe for concurrent searches.
+type Factory func() Engine
+
+type Difficulty int
+const (
+	Random Difficulty = iota
+	Greedy
+	Perfect
+	MCTS
+)
+
+func (difficulty Difficulty) String() string {
+	switch difficulty {
+	case Random:
+		return "random"
+	case Greedy:
+		return "greedy"
+	case Perfect:
+		return "perfect"
+	case MCTS:
+		return "mcts"
+	default:
+		assert.Never("unknown difficulty", "difficulty", int(difficulty))
+		return "unknown"
+	}
+}
+
+func ParseDifficulty(name string) (Difficulty, error) {
+	for _, difficulty := range []Difficulty{Random, Greedy, Perfect, MCTS} {
+		if difficulty.String() == name {
+			return difficulty, nil
+		}
+	}
+
+	return Random, errors.New("unknown difficulty")
+}
+
+func CreateEngine(difficulty Difficulty, seed int64) Engine {
+	rng := rand.New(rand.NewSource(seed))
+
+	switch difficulty {
+	case Random:
+		return CreateRandom(rng)
+	case Greedy:
+		return CreateGreedy(rng)
+	case Perfect:
+		return CreateAlphaBeta(DefaultMaxDepth, DefaultMaxNodes, rng)
+	case MCTS:
+		return CreateMCTS(MCTSConfig{
+			Iterations: DefaultMCTSIterations,
+			Duration: DefaultMCTSDuration,
+			Seed: seed,
+		})
+	default:
+		assert.Never("unknown difficulty", "difficulty", int(difficulty))
+		return nil
+	}
+}
+
+// play returns copy of state after the move.
+func play(state gameRules.Searchable, move gameRules.Move) gameRules.Searchable {
+	child := state.Clone()
+	_, err := child.ApplyMove(child.GetCurrentPlayer(), move)
+	assert.NoError(err, "legal move was rejected")
+
+	return child
+}
+
+func isWinOf(state gameRules.Searchable, playerId int) bool {
+	outcome := state.GetOutcome()
+
+	return winState.IsWin(outcome) && outcome.GetPlayer().Id == playerId
+}
@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"GridPlay/connectFour"
+	"GridPlay/game"
+	"GridPlay/game/winState"
+	"GridPlay/gameRules"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createTicTacToe(t *testing.T, moves ...game.Pos) gameRules.Searchable {
+	rules, err := game.CreateRules(nil)
+	require.NoError(t, err)
+
+	state := rules.(gameRules.Searchable)
+	for _, pos := range moves {
+		_, err := state.ApplyMove(state.GetCurrentPlayer(), pos)
+		require.NoError(t, err)
+	}
+
+	return state
+}
+
+func playOut(t *testing.T, state gameRules.Searchable, engines [2]Engine) []gameRules.Move {
+	moves := []gameRules.Move{}
+
+	for state.GetOutcome() == winState.Values.None {
+		move := engines[state.GetCurrentPlayer()].ChooseMove(state)
+		_, err := state.ApplyMove(state.GetCurrentPlayer(), move)
+		require.NoError(t, err)
+
+		moves = append(moves, move)
+	}
+
+	return moves
+}
+
+func TestParseDifficulty(t *testing.T) {
+	for _, difficulty := range []Difficulty{Random, Greedy, Perfect, MCTS} {
+		parsed, err := ParseDifficulty(difficulty.String())
+		require.NoError(t, err)
+		require.Equal(t, difficulty, parsed)
+	}
+
+	_, err := ParseDifficulty("impossible")
+	require.Error(t, err)
+}
+
+func TestEnginesTakeWin(t *testing.T) {
+	// Player 0 has (0,0) and (1,1), wins with (2,2).
+	state := createTicTacToe(t, game.Pos{X: 0, Y: 0}, game.Pos{X: 0, Y: 1}, game.Pos{X: 1, Y: 1}, game.Pos{X: 0, Y: 2})
+
+	for _, difficulty := range []Difficulty{Greedy, Perfect} {
+		engine := CreateEngine(difficulty, 1)
+		require.Equal(t, game.Pos{X: 2, Y: 2}, engine.ChooseMove(state), difficulty.String())
+	}
+}
+
+func TestEnginesBlock(t *testing.T) {
+	// Player 1 must block (2,0).
+	state := createTicTacToe(t, game.Pos{X: 0, Y: 0}, game.Pos{X: 1, Y: 1}, game.Pos{X: 1, Y: 0})
+
+	for _, difficulty := range []Difficulty{Greedy, Perfect} {
+		engine := CreateEngine(difficulty, 1)
+		require.Equal(t, game.Pos{X: 2, Y: 0}, engine.ChooseMove(state), difficulty.String())
+	}
+}
+
+func TestPerfectNeverLoses(t *testing.T) {
+	for seed := range int64(4) {
+		perfect := CreateEngine(Perfect, seed)
+
+		state := createTicTacToe(t)
+		playOut(t, state, [2]Engine{perfect, perfect})
+		require.Equal(t, winState.Values.Draw, state.GetOutcome())
+
+		state = createTicTacToe(t)
+		playOut(t, state, [2]Engine{CreateEngine(Random, seed), perfect})
+		require.False(t, isWinOf(state, 0))
+	}
+}
+
+func TestRandomIsDeterministic(t *testing.T) {
+	first := createTicTacToe(t)
+	second := createTicTacToe(t)
+
+	firstMoves := playOut(t, first, [2]Engine{CreateEngine(Random, 7), CreateEngine(Random, 8)})
+	secondMoves := playOut(t, second, [2]Engine{CreateEngine(Random, 7), CreateEngine(Random, 8)})
+
+	require.Equal(t, firstMoves, secondMoves)
+}
+
+func TestNoLegalMoves(t *testing.T) {
+	state := createTicTacToe(t, game.Pos{X: 0, Y: 0}, game.Pos{X: 0, Y: 1}, game.Pos{X: 1, Y: 1}, game.Pos{X: 0, Y: 2}, game.Pos{X: 2, Y: 2})
+
+	require.Nil(t, CreateEngine(Perfect, 1).ChooseMove(state))
+}
+
+func TestAlphaBetaRespectsNodeLimit(t *testing.T) {
+	rules, err := connectFour.CreateRules(nil)
+	require.NoError(t, err)
+
+	move := CreateEngine(Perfect, 1).ChooseMove(rules.(gameRules.Searchable))
+	require.NotNil(t, move)
+}
@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"GridPlay/gameRules"
+	"math/rand"
+)
+
+// GreedyEngine looks one move ahead: wins if it can, otherwise avoids
+// moves that let the opponent win immediately.
+type GreedyEngine struct {
+	rng *rand.Rand
+}
+
+func CreateGreedy(rng *rand.Rand) *GreedyEngine {
+	return &GreedyEngine{
+		rng: rng,
+	}
+}
+
+func (engine *GreedyEngine) ChooseMove(state gameRules.Searchable) gameRules.Move {
+	moves := state.GetLegalMoves()
+
+	if len(moves) == 0 {
+		return nil
+	}
+
+	playerId := state.GetCurrentPlayer()
+	safe := []gameRules.Move{}
+
+	for _, move := range moves {
+		child := play(state, move)
+
+		if isWinOf(child, playerId) {
+			return move
+		}
+
+		if !engine.opponentCanWin(child, playerId) {
+			safe = append(safe, move)
+		}
+	}
+
+	if len(safe) == 0 {
+		safe = moves
+	}
+
+	return safe[engine.rng.Intn(len(safe))]
+}
+
+func (engine *GreedyEngine) opponentCanWin(state gameRules.Searchable, playerId int) bool {
+	opponentId := state.GetCurrentPlayer()
+
+	if opponentId == playerId {
+		return false
+	}
+
+	for _, move := range state.GetLegalMoves() {
+		if isWinOf(play(state, move), opponentId) {
+			return true
+		}
+	}
+
+	return false
+}
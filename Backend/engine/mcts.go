@@ -0,0 +1,203 @@
+package engine
+
+import (
+	"GridPlay/assert"
+	"GridPlay/game/winState"
+	"GridPlay/gameRules"
+	"cmp"
+	"math"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+const (
+	DefaultMCTSIterations = 50000
+	DefaultMCTSDuration = time.Second
+)
+
+// MCTSConfig limits the search by iterations, by time or by both, whichever ends first.
+// Zero means no limit, at least one limit must be set. Search limited only by
+// iterations is deterministic for a given seed.
+type MCTSConfig struct {
+	Iterations int
+	Duration time.Duration
+	// UCT exploration constant, sqrt(2) when zero.
+	Exploration float64
+	Seed int64
+}
+
+// MoveStats is result of the search for one move of the current player.
+type MoveStats struct {
+	Move gameRules.Move `json:"move"`
+	Visits int `json:"visits"`
+	// Expected score of the move for the current player, 1 is a win, 0.5 a draw.
+	Value float64 `json:"value"`
+}
+
+// MCTSEngine is Monte Carlo Tree Search with UCT selection and random playouts.
+type MCTSEngine struct {
+	config MCTSConfig
+	rng *rand.Rand
+}
+
+type mctsNode struct {
+	move gameRules.Move
+	parent *mctsNode
+	children []*mctsNode
+	untried []gameRules.Move
+	// Player who made the move leading to this node.
+	playerId int
+	visits int
+	score float64
+}
+
+func CreateMCTS(config MCTSConfig) *MCTSEngine {
+	assert.Assert(config.Iterations > 0 || config.Duration > 0, "mcts search must be limited")
+
+	if config.Exploration == 0 {
+		config.Exploration = math.Sqrt2
+	}
+
+	return &MCTSEngine{
+		config: config,
+		rng: rand.New(rand.NewSource(config.Seed)),
+	}
+}
+
+func (engine *MCTSEngine) ChooseMove(state gameRules.Searchable) gameRules.Move {
+	stats := engine.Analyze(state)
+
+	if len(stats) == 0 {
+		return nil
+	}
+
+	return stats[0].Move
+}
+
+// Analyze returns stats of all legal moves, the most visited first.
+func (engine *MCTSEngine) Analyze(state gameRules.Searchable) []MoveStats {
+	root := engine.createNode(state, nil, nil, -1)
+
+	if len(root.untried) == 0 {
+		return []MoveStats{}
+	}
+
+	deadline := time.Now().Add(engine.config.Duration)
+
+	for i := 0; engine.config.Iterations == 0 || i < engine.config.Iterations; i++ {
+		if engine.config.Duration > 0 && time.Now().After(deadline) {
+			break
+		}
+
+		engine.iterate(root, state.Clone())
+	}
+
+	stats := make([]MoveStats, 0, len(root.children))
+	for _, child := range root.children {
+		value := 0.0
+		if child.visits > 0 {
+			value = child.score / float64(child.visits)
+		}
+
+		stats = append(stats, MoveStats{
+			Move: child.move,
+			Visits: child.visits,
+			Value: value,
+		})
+	}
+
+	slices.SortStableFunc(stats, func(a, b MoveStats) int {
+		if a.Visits != b.Visits {
+			return b.Visits - a.Visits
+		}
+		return cmp.Compare(b.Value, a.Value)
+	})
+
+	return stats
+}
+
+func (engine *MCTSEngine) createNode(state gameRules.Searchable, move gameRules.Move, parent *mctsNode, playerId int) *mctsNode {
+	return &mctsNode{
+		move: move,
+		parent: parent,
+		untried: state.GetLegalMoves(),
+		playerId: playerId,
+	}
+}
+
+// iterate runs selection, expansion, playout and backpropagation. State is modified.
+func (engine *MCTSEngine) iterate(root *mctsNode, state gameRules.Searchable) {
+	node := root
+
+	for len(node.untried) == 0 && len(node.children) > 0 {
+		node = engine.selectChild(node)
+		applyInPlace(state, node.move)
+	}
+
+	if len(node.untried) > 0 {
+		i := engine.rng.Intn(len(node.untried))
+		move := node.untried[i]
+		node.untried[i] = node.untried[len(node.untried) - 1]
+		node.untried = node.untried[:len(node.untried) - 1]
+
+		playerId := state.GetCurrentPlayer()
+		applyInPlace(state, move)
+
+		child := engine.createNode(state, move, node, playerId)
+		node.children = append(node.children, child)
+		node = child
+	}
+
+	outcome := engine.playout(state)
+
+	for ; node != nil; node = node.parent {
+		node.visits++
+		node.score += scoreFor(outcome, node.playerId)
+	}
+}
+
+func (engine *MCTSEngine) selectChild(node *mctsNode) *mctsNode {
+	var best *mctsNode
+	bestValue := math.Inf(-1)
+	logVisits := math.Log(float64(node.visits))
+
+	for _, child := range node.children {
+		exploitation := child.score / float64(child.visits)
+		exploration := engine.config.Exploration * math.Sqrt(logVisits / float64(child.visits))
+
+		if value := exploitation + exploration; value > bestValue {
+			best, bestValue = child, value
+		}
+	}
+
+	assert.NotNil(best, "node without children was selected")
+	return best
+}
+
+func (engine *MCTSEngine) playout(state gameRules.Searchable) winState.WinState {
+	for state.GetOutcome() == winState.Values.None {
+		moves := state.GetLegalMoves()
+		assert.Assert(len(moves) > 0, "unfinished game without legal moves")
+
+		applyInPlace(state, moves[engine.rng.Intn(len(moves))])
+	}
+
+	return state.GetOutcome()
+}
+
+func applyInPlace(state gameRules.Searchable, move gameRules.Move) {
+	_, err := state.ApplyMove(state.GetCurrentPlayer(), move)
+	assert.NoError(err, "legal move was rejected")
+}
+
+func scoreFor(outcome winState.WinState, playerId int) float64 {
+	if !winState.IsWin(outcome) {
+		return 0.5
+	}
+
+	if outcome.GetPlayer().Id == playerId {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"GridPlay/game"
+	"GridPlay/gameRules"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createGomoku(t *testing.T) gameRules.Searchable {
+	rules, err := game.CreateGomokuRules(nil)
+	require.NoError(t, err)
+
+	return rules.(gameRules.Searchable)
+}
+
+func TestMCTSTakesWin(t *testing.T) {
+	state := createTicTacToe(t, game.Pos{X: 0, Y: 0}, game.Pos{X: 0, Y: 1}, game.Pos{X: 1, Y: 1}, game.Pos{X: 0, Y: 2})
+
+	mcts := CreateMCTS(MCTSConfig{Iterations: 2000, Seed: 1})
+	require.Equal(t, game.Pos{X: 2, Y: 2}, mcts.ChooseMove(state))
+}
+
+func TestMCTSBlocks(t *testing.T) {
+	state := createTicTacToe(t, game.Pos{X: 0, Y: 0}, game.Pos{X: 1, Y: 1}, game.Pos{X: 1, Y: 0})
+
+	mcts := CreateMCTS(MCTSConfig{Iterations: 5000, Seed: 1})
+	require.Equal(t, game.Pos{X: 2, Y: 0}, mcts.ChooseMove(state))
+}
+
+func TestMCTSDeterministic(t *testing.T) {
+	state := createGomoku(t)
+
+	first := CreateMCTS(MCTSConfig{Iterations: 300, Seed: 42}).Analyze(state)
+	second := CreateMCTS(MCTSConfig{Iterations: 300, Seed: 42}).Analyze(state)
+
+	require.Equal(t, first, second)
+	require.Len(t, first, 15 * 15)
+	require.GreaterOrEqual(t, first[0].Visits, first[len(first) - 1].Visits)
+}
+
+func TestMCTSDoesNotChangeState(t *testing.T) {
+	state := createTicTacToe(t, game.Pos{X: 1, Y: 1})
+	before := state.GetState()
+
+	CreateMCTS(MCTSConfig{Iterations: 100, Seed: 1}).ChooseMove(state)
+
+	require.Equal(t, before, state.GetState())
+}
+
+func TestMCTSTimeLimit(t *testing.T) {
+	state := createGomoku(t)
+	start := time.Now()
+
+	move := CreateMCTS(MCTSConfig{Duration: 50 * time.Millisecond, Seed: 1}).ChooseMove(state)
+
+	require.NotNil(t, move)
+	require.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,26 @@
+package engine
+
+import (
+	"GridPlay/gameRules"
+	"math/rand"
+)
+
+type RandomEngine struct {
+	rng *rand.Rand
+}
+
+func CreateRandom(rng *rand.Rand) *RandomEngine {
+	return &RandomEngine{
+		rng: rng,
+	}
+}
+
+func (engine *RandomEngine) ChooseMove(state gameRules.Searchable) gameRules.Move {
+	moves := state.GetLegalMoves()
+
+	if len(moves) == 0 {
+		return nil
+	}
+
+	return moves[engine.rng.Intn(len(moves))]
+}
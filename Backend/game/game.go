@@ -5,7 +5,11 @@ import (
 	"GridPlay/game/winState"
 	"container/list"
 	"errors"
-	"math"
+)
+
+const (
+	DefaultSize = 3
+	MaxSize = 32
 )
 
 type Player struct {
@@ -29,11 +33,33 @@ type Pos struct {
 type Game struct {
 	players [2]Player
 	state [][]char
+	width int
+	height int
+	winLength int
+	// Player to move before the first move in history, other than 0 for games loaded from a position.
+	startPlayer int
 	winState winState.WinState
 	moveHistory list.List
 }
 
+// CreateGame creates classic 3x3 tic-tac-toe.
 func CreateGame() *Game {
+	game, err := CreateCustomGame(DefaultSize, DefaultSize, DefaultSize)
+	assert.NoError(err, "cannot create default game")
+
+	return game
+}
+
+// CreateCustomGame creates m,n,k-game: width x height board, winLength in a row wins.
+func CreateCustomGame(width, height, winLength int) (*Game, error) {
+	if width < 1 || height < 1 || width > MaxSize || height > MaxSize {
+		return nil, errors.New("board size is out of range")
+	}
+
+	if winLength < 1 || (winLength > width && winLength > height) {
+		return nil, errors.New("win length does not fit on the board")
+	}
+
 	p1 := Player{
 		char: RandomChar(),
 		id: 0,
@@ -45,26 +71,121 @@ func CreateGame() *Game {
 
 	game := &Game{
 		players: [2]Player{p1, p2},
-		state: createEmptyState(),
+		state: createEmptyState(width, height),
+		width: width,
+		height: height,
+		winLength: winLength,
 		winState: winState.Values.None,
-		moveHistory: *list.New(),
+		moveHistory: list.List{},
 	}
 
-	return game
+	return game, nil
 }
 
-func createEmptyState() [][]char {
-	rows := make([][]char, 3)
+// Clone returns deep copy of the game.
+func (game *Game) Clone() *Game {
+	clone := &Game{
+		players: game.players,
+		state: createEmptyState(game.width, game.height),
+		width: game.width,
+		height: game.height,
+		winLength: game.winLength,
+		startPlayer: game.startPlayer,
+		winState: game.winState,
+		moveHistory: list.List{},
+	}
+
+	for x := range game.state {
+		copy(clone.state[x], game.state[x])
+	}
+
+	for m := game.moveHistory.Front(); m != nil; m = m.Next() {
+		clone.moveHistory.PushBack(m.Value)
+	}
+
+	return clone
+}
+
+// GetEmptyCells returns all positions where a move can be made.
+func (game *Game) GetEmptyCells() []Pos {
+	cells := []Pos{}
+
+	if game.winState != winState.Values.None {
+		return cells
+	}
+
+	for x := range game.width {
+		for y := range game.height {
+			if game.state[x][y] == e {
+				cells = append(cells, Pos{x, y})
+			}
+		}
+	}
+
+	return cells
+}
+
+func createEmptyState(width, height int) [][]char {
+	rows := make([][]char, width)
 
 	for i := range rows {
-		rows[i] = make([]char, 3)
+		rows[i] = make([]char, height)
 	}
 
 	return rows
 }
 
-func areEqual[t comparable](v1, v2, v3 t) bool {
-	return v1 == v2 && v2 == v3;
+func (game *Game) GetWidth() int {
+	return game.width
+}
+
+func (game *Game) GetHeight() int {
+	return game.height
+}
+
+func (game *Game) GetWinLength() int {
+	return game.winLength
+}
+
+func (game *Game) IsEmpty(pos Pos) bool {
+	assert.Assert(game.inBounds(pos), "position is out of range", "pos", pos)
+
+	return game.state[pos.X][pos.Y] == e
+}
+
+// GetBoard returns copy of the board as runes, indexed [x][y].
+func (game *Game) GetBoard() [][]rune {
+	board := make([][]rune, game.width)
+
+	for x, column := range game.state {
+		board[x] = make([]rune, game.height)
+
+		for y, c := range column {
+			board[x][y] = c.GetRune()
+		}
+	}
+
+	return board
+}
+
+func (game *Game) inBounds(pos Pos) bool {
+	return pos.X >= 0 && pos.Y >= 0 && pos.X < game.width && pos.Y < game.height
+}
+
+// Horizontal, vertical and both diagonals.
+var lineDirections = [4]Pos{{1, 0}, {0, 1}, {1, 1}, {1, -1}}
+
+// countInDirection counts cells with char c starting next to pos and going in dir.
+func (game *Game) countInDirection(pos, dir Pos, c char) int {
+	count := 0
+	curr := Pos{pos.X + dir.X, pos.Y + dir.Y}
+
+	for game.inBounds(curr) && game.state[curr.X][curr.Y] == c {
+		count++
+		curr = Pos{curr.X + dir.X, curr.Y + dir.Y}
+	}
+
+	return count
 }
 
 func (game *Game) checkWinnerByLastMove() char {
@@ -79,38 +200,55 @@ func (game *Game) checkWinnerByLastMove() char {
 
 	pos := lastMove.pos
 
-    if areEqual(state[pos.X][0], state[pos.X][1], state[pos.X][2]) && state[pos.X][0] != e {
-        winner = state[pos.X][0]
-    }
-    if areEqual(state[0][pos.Y], state[1][pos.Y], state[2][pos.Y]) && state[0][pos.Y] != e {
-        winner = state[0][pos.Y]
-    }
-    if areEqual(state[0][0], state[1][1], state[2][2]) && state[0][0] != e {
-        winner = state[0][0]
-    }
-    if areEqual(state[2][0], state[1][1], state[0][2]) && state[2][0] != e {
-        winner = state[2][0]
-    }
+	if game.isInWinningLine(pos) {
+		winner = state[pos.X][pos.Y]
+	}
 
 	assert.Assert(winner >= 0 && winner <= 2, "winner out of range", "winner", winner)
     return winner
 }
 
+// isInWinningLine checks if the non empty cell at pos is a part of winLength cells in a row.
+func (game *Game) isInWinningLine(pos Pos) bool {
+	c := game.state[pos.X][pos.Y]
+
+	if c == e {
+		return false
+	}
+
+	for _, dir := range lineDirections {
+		backward := Pos{-dir.X, -dir.Y}
+		length := 1 + game.countInDirection(pos, dir, c) + game.countInDirection(pos, backward, c)
+
+		if length >= game.winLength {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (game *Game) checkDraw() bool {
-    return game.moveHistory.Len() == int(math.Pow(3.0, 2.0))
+	for _, column := range game.state {
+		for _, c := range column {
+			if c == e {
+				return false
+			}
+		}
+	}
+
+	return true
 }
 
 func (game *Game) Move(pos Pos) error {
-	if pos.X < 0 || pos.Y < 0 || pos.X > 2 || pos.Y > 2 {
-		assert.Never("position is out of range", "pos", pos)
-	}
+	err := game.ValidateMove(pos)
 
-	if game.winState != winState.Values.None {
-		return errors.New("cannot move after game ended")
+	if err != nil {
+		return err
 	}
 		
 	p := game.GetCurrentRoundPlayer()
-	err := game.check(pos, p.char)
+	err = game.check(pos, p.char)
 
 	if err != nil {
 		return err
@@ -122,10 +260,7 @@ func (game *Game) Move(pos Pos) error {
 	})
 
 	if game.checkWinnerByLastMove() != e {
-		state := winState.Values.Win
-		state.Player = winState.Player{Id: p.id, Char: int(p.char)}
-
-		game.winState = state
+		game.winState = winState.CreateWin(winState.Player{Id: p.id, Char: int(p.char)})
 	} else if game.checkDraw() {
 		game.winState = winState.Values.Draw
 	}
@@ -133,10 +268,25 @@ func (game *Game) Move(pos Pos) error {
 	return nil
 }
 
-func (game *Game) check(pos Pos, c char) error {
-	if pos.X < 0 || pos.Y < 0 || pos.X > 2 || pos.Y > 2 {
-		assert.Never("position is out of range", "pos", pos)
+// ValidateMove checks if current round player can move to pos, without changing the game.
+func (game *Game) ValidateMove(pos Pos) error {
+	if !game.inBounds(pos) {
+		return errors.New("position is out of range")
+	}
+
+	if game.winState != winState.Values.None {
+		return errors.New("cannot move after game ended")
+	}
+
+	if game.state[pos.X][pos.Y] != e {
+		return errors.New("cell is not empty")
 	}
+
+	return nil
+}
+
+func (game *Game) check(pos Pos, c char) error {
+	assert.Assert(game.inBounds(pos), "position is out of range", "pos", pos)
 	assert.Assert(c >= 0 && c <= 2, "char out of range", "char", c)
 
 	if game.state[pos.X][pos.Y] != e {
@@ -148,6 +298,42 @@ func (game *Game) check(pos Pos, c char) error {
 	return nil
 }
 
+// Undo takes back the last move, the player who made it is on the move again.
+func (game *Game) Undo() error {
+	lastMove, err := game.getLastMove()
+
+	if err != nil {
+		return err
+	}
+
+	game.moveHistory.Remove(game.moveHistory.Front())
+	game.state[lastMove.pos.X][lastMove.pos.Y] = e
+
+	// Nobody could move after the game ended, so the last move ended it.
+	game.winState = winState.Values.None
+
+	return nil
+}
+
+func (game *Game) GetMoveCount() int {
+	return game.moveHistory.Len()
+}
+
+// GetMoveNumber returns number of moves played, including those of a loaded position.
+// Pieces are never removed, so it is the number of pieces on the board.
+func (game *Game) GetMoveNumber() int {
+	moves := 0
+
+	for _, column := range game.state {
+		for _, c := range column {
+			if c != e {
+				moves++
+			}
+		}
+	}
+
+	return moves
+}
 
 func (game *Game) GetWinState() winState.WinState {
 	return game.winState
@@ -162,7 +348,7 @@ func (game *Game) GetCurrentRoundPlayer() Player {
 	lastMove, err := game.getLastMove()
 
 	if err != nil {
-		return game.players[0]
+		return game.players[game.startPlayer]
 	}
 
 	return game.players[1 - lastMove.playerID]
@@ -181,6 +367,13 @@ func (game *Game) getLastMove() (move, error) {
 	return m, nil
 }
 
+// SwapChars exchanges chars of the players, the board must be empty.
+func (game *Game) SwapChars() {
+	assert.Assert(game.GetMoveNumber() == 0, "cannot swap chars after the first move")
+
+	game.players[0].char, game.players[1].char = game.players[1].char, game.players[0].char
+}
+
 func (game *Game) GetPlayerWithId(id int) Player {
 	if id < 0 || id > 1 {
 		assert.Never("player id must be 0 or 1", "player id", id)
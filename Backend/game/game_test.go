@@ -3,7 +3,6 @@ package game
 import (
 	"GridPlay/assert"
 	"GridPlay/game/winState"
-	"container/list"
 	"os"
 	"testing"
 
@@ -34,7 +33,7 @@ func TestGameEnd(t *testing.T) {
 	
 	state := game.GetWinState()
 
-	require.Equal(t, state, winState.Values.Win)
+	require.True(t, winState.IsWin(state))
 	winner := state.GetPlayer()
 
 	require.Equal(t, winner.Id, 0) // 0 = id of player 1
@@ -61,72 +60,29 @@ func TestGameDraw(t *testing.T) {
 }
 
 func TestGameWinChecker(t *testing.T) {
-	// Horizontal.
-	for i := range 3 {
-		chk := createEmptyState()
-		chk[0][i] = x
-		chk[1][i] = x
-		chk[2][i] = x
-
-		moveHistory := list.New()
-		moveHistory.PushFront(move{pos: Pos{2, i}, playerID: 0})
-
-		chkg := Game{
-			state: chk,
-			moveHistory: *moveHistory, // only last move
-		}
-
-		require.Equal(t, chkg.checkWinnerByLastMove(), char(x))
-	}
-	// Vertical.
-	for i := range 3 {
-		chk := createEmptyState()
-		chk[i][0] = x
-		chk[i][1] = x
-		chk[i][2] = x
-
-		moveHistory := list.New()
-		moveHistory.PushFront(move{pos: Pos{i, 2}, playerID: 0})
-
-		chkg := Game{
-			state: chk,
-			moveHistory: *moveHistory,
-		}
-
-		require.Equal(t, chkg.checkWinnerByLastMove(), char(x))
-	}
-	//
-	{
-		chk := createEmptyState()
-		chk[0][0] = x
-		chk[1][1] = x
-		chk[2][2] = x
-
-		moveHistory := list.New()
-		moveHistory.PushFront(move{pos: Pos{2, 2}, playerID: 0})
-
-		chkg := Game{
-			state: chk,
-			moveHistory: *moveHistory,
-		}
-
-		require.Equal(t, chkg.checkWinnerByLastMove(), char(x))
-	}
-	{
-		chk := createEmptyState()
-		chk[0][2] = x
-		chk[1][1] = x
-		chk[2][0] = x
-
-		moveHistory := list.New()
-		moveHistory.PushFront(move{pos: Pos{2, 0}, playerID: 0})
-
-		chkg := Game{
-			state: chk,
-			moveHistory: *moveHistory,
-		}
-
-		require.Equal(t, chkg.checkWinnerByLastMove(), char(x))
+	// Positions with x to move and the move completing a line.
+	for _, tc := range []struct {
+		position string
+		move Pos
+	}{
+		// Horizontal.
+		{"xx./oo./... x 4 3", Pos{2, 0}},
+		{"oo./xx./... x 4 3", Pos{2, 1}},
+		{"oo./.../.xx x 4 3", Pos{0, 2}},
+		// Vertical.
+		{"xo./xo./... x 4 3", Pos{0, 2}},
+		{".xo/.xo/... x 4 3", Pos{1, 2}},
+		{"o.x/o../..x x 4 3", Pos{2, 1}},
+		// Diagonals.
+		{"xo./ox./... x 4 3", Pos{2, 2}},
+		{"oo./.x./x.. x 4 3", Pos{2, 0}},
+	} {
+		game, err := Parse(tc.position)
+		require.NoError(t, err, tc.position)
+		require.Equal(t, e, game.checkWinnerByLastMove())
+
+		require.NoError(t, game.Move(tc.move))
+		require.Equal(t, x, game.checkWinnerByLastMove(), tc.position)
 	}
 }
 
@@ -147,4 +103,99 @@ func TestRandomChar(t *testing.T) {
 		require.Greater(t, randomChar, e)
 		require.LessOrEqual(t, randomChar, o)
 	}
-}
\ No newline at end of file
+}
+
+func TestCustomGameSize(t *testing.T) {
+	_, err := CreateCustomGame(0, 3, 3)
+	require.Error(t, err)
+
+	_, err = CreateCustomGame(MaxSize + 1, 3, 3)
+	require.Error(t, err)
+
+	_, err = CreateCustomGame(3, 3, 4)
+	require.Error(t, err)
+
+	game, err := CreateCustomGame(15, 15, 5)
+	require.NoError(t, err)
+	require.Error(t, game.Move(Pos{15, 0}))
+	require.NoError(t, game.Move(Pos{14, 14}))
+}
+
+func TestGomokuWin(t *testing.T) {
+	game, err := CreateCustomGame(15, 15, 5)
+	require.NoError(t, err)
+
+	// Player 0 builds anti-diagonal from (10,4) to (6,8), last stone in the middle.
+	first := []Pos{{10, 4}, {9, 5}, {7, 7}, {6, 8}, {8, 6}}
+	second := []Pos{{0, 0}, {0, 1}, {0, 2}, {0, 3}}
+
+	for i := range second {
+		require.NoError(t, game.Move(first[i]))
+		require.Equal(t, winState.Values.None, game.GetWinState())
+		require.NoError(t, game.Move(second[i]))
+	}
+
+	require.NoError(t, game.Move(first[4]))
+	require.True(t, winState.IsWin(game.GetWinState()))
+	require.Equal(t, 0, game.GetWinState().GetPlayer().Id)
+}
+
+func TestGomokuFourIsNotWin(t *testing.T) {
+	game, err := CreateCustomGame(15, 15, 5)
+	require.NoError(t, err)
+
+	for i := range 4 {
+		require.NoError(t, game.Move(Pos{i, 7}))
+		require.NoError(t, game.Move(Pos{i, 0}))
+	}
+
+	require.Equal(t, winState.Values.None, game.GetWinState())
+}
+
+func TestRectangularDraw(t *testing.T) {
+	game, err := CreateCustomGame(2, 1, 2)
+	require.NoError(t, err)
+
+	require.NoError(t, game.Move(Pos{0, 0}))
+	require.NoError(t, game.Move(Pos{1, 0}))
+	require.Equal(t, winState.Values.Draw, game.GetWinState())
+}
+func TestGameUndo(t *testing.T) {
+	game := CreateGame()
+	require.Error(t, game.Undo())
+
+	require.NoError(t, game.Move(Pos{0,0}))
+	require.NoError(t, game.Move(Pos{1,0}))
+	require.NoError(t, game.Move(Pos{1,1}))
+	require.NoError(t, game.Move(Pos{2,1}))
+	require.NoError(t, game.Move(Pos{2,2}))
+	require.True(t, winState.IsWin(game.GetWinState()))
+
+	require.NoError(t, game.Undo())
+	require.Equal(t, winState.Values.None, game.GetWinState())
+	require.True(t, game.IsEmpty(Pos{2,2}))
+	require.Equal(t, 4, game.GetMoveCount())
+
+	player := game.GetCurrentRoundPlayer()
+	require.Equal(t, 0, player.GetID())
+
+	require.NoError(t, game.Undo())
+	player = game.GetCurrentRoundPlayer()
+	require.Equal(t, 1, player.GetID())
+	require.NoError(t, game.Move(Pos{2,2}))
+}
+
+func TestGameSwapChars(t *testing.T) {
+	game := CreateGame()
+	p0 := game.GetPlayerWithId(0)
+	p1 := game.GetPlayerWithId(1)
+
+	game.SwapChars()
+	swapped0 := game.GetPlayerWithId(0)
+	swapped1 := game.GetPlayerWithId(1)
+	require.Equal(t, p1.GetChar(), swapped0.GetChar())
+	require.Equal(t, p0.GetChar(), swapped1.GetChar())
+
+	require.NoError(t, game.Move(Pos{0,0}))
+	require.Equal(t, p1.GetChar().GetRune(), game.GetBoard()[0][0])
+}
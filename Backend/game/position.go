@@ -0,0 +1,204 @@
+package game
+
+import (
+	"GridPlay/game/winState"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Position notation has four fields separated by spaces, e.g. "x.o/.x./... o 3 3":
+//
+//   - board, rows are listed from y = 0 and separated by '/', every row lists cells
+//     from x = 0: 'x', 'o' or '.' for an empty cell
+//   - side to move, 'x' or 'o'
+//   - number of moves played, it must match the number of pieces
+//   - win length
+//
+// Format(Parse(text)) returns the same text.
+
+// Parse creates game from a position in notation. Player 0 plays x, player 1 plays o.
+func Parse(text string) (*Game, error) {
+	fields := strings.Fields(text)
+
+	if len(fields) != 4 {
+		return nil, errors.New("position must have board, side to move, move counter and win length")
+	}
+
+	winLength, err := strconv.Atoi(fields[3])
+	if err != nil {
+		return nil, errors.New("win length must be a number")
+	}
+
+	game, count, err := parseBoard(fields[0], winLength)
+	if err != nil {
+		return nil, err
+	}
+
+	side, err := parseCell(fields[1])
+	if err != nil || side == e {
+		return nil, errors.New("side to move must be 'x' or 'o'")
+	}
+
+	moves, err := strconv.Atoi(fields[2])
+	if err != nil || moves != count[0] + count[1] {
+		return nil, errors.New("move counter must be the number of pieces on the board")
+	}
+
+	game.startPlayer = game.getPlayerWithChar(side).id
+	opponentCount := count[1 - game.startPlayer]
+
+	// Side to move has as many pieces as the opponent, or one less if the opponent started.
+	if diff := opponentCount - count[game.startPlayer]; diff != 0 && diff != 1 {
+		return nil, errors.New("side to move does not match the number of pieces")
+	}
+
+	game.winState, err = game.checkBoardOutcome()
+	if err != nil {
+		return nil, err
+	}
+
+	return game, nil
+}
+
+// Format writes the game in position notation.
+func Format(game *Game) string {
+	rows := make([]string, game.height)
+
+	for y := range game.height {
+		var row strings.Builder
+
+		for px := range game.width {
+			c := game.state[px][y]
+
+			if c == e {
+				row.WriteRune('.')
+			} else {
+				row.WriteRune(c.GetRune())
+			}
+		}
+
+		rows[y] = row.String()
+	}
+
+	player := game.GetCurrentRoundPlayer()
+
+	return fmt.Sprintf("%s %c %d %d", strings.Join(rows, "/"), player.char.GetRune(), game.GetMoveNumber(), game.winLength)
+}
+
+// ParsePosition creates game from the board field of position notation alone, e.g. "x.o/.x./..o".
+// x always moves first, so side to move follows from the number of pieces.
+func ParsePosition(text string, winLength int) (*Game, error) {
+	game, count, err := parseBoard(text, winLength)
+	if err != nil {
+		return nil, err
+	}
+
+	switch count[0] - count[1] {
+	case 0:
+		game.startPlayer = 0
+	case 1:
+		game.startPlayer = 1
+	default:
+		return nil, errors.New("x moves first, so it must have the same number of pieces as o or one more")
+	}
+
+	game.winState, err = game.checkBoardOutcome()
+	if err != nil {
+		return nil, err
+	}
+
+	return game, nil
+}
+
+// parseBoard creates game with x for player 0 and o for player 1, and counts pieces of each player.
+func parseBoard(text string, winLength int) (*Game, [2]int, error) {
+	var count [2]int
+
+	rows := strings.Split(text, "/")
+	width := len([]rune(rows[0]))
+
+	game, err := CreateCustomGame(width, len(rows), winLength)
+	if err != nil {
+		return nil, count, err
+	}
+
+	game.players = [2]Player{{char: x, id: 0}, {char: o, id: 1}}
+
+	for y, row := range rows {
+		cells := []rune(row)
+
+		if len(cells) != width {
+			return nil, count, errors.New("all rows must have the same length")
+		}
+
+		for px, r := range cells {
+			c, err := parseCell(string(r))
+			if err != nil {
+				return nil, count, err
+			}
+
+			if c != e {
+				count[game.getPlayerWithChar(c).id]++
+			}
+
+			game.state[px][y] = c
+		}
+	}
+
+	return game, count, nil
+}
+
+func parseCell(s string) (char, error) {
+	switch s {
+	case ".":
+		return e, nil
+	case "x":
+		return x, nil
+	case "o":
+		return o, nil
+	default:
+		return e, errors.New("cell must be one of 'x', 'o' or '.'")
+	}
+}
+
+func (game *Game) getPlayerWithChar(c char) Player {
+	if game.players[0].char == c {
+		return game.players[0]
+	}
+	return game.players[1]
+}
+
+// checkBoardOutcome scans the whole board, used when there is no last move.
+func (game *Game) checkBoardOutcome() (winState.WinState, error) {
+	var winner *Player
+
+	for px := range game.width {
+		for py := range game.height {
+			if !game.isInWinningLine(Pos{px, py}) {
+				continue
+			}
+
+			p := game.getPlayerWithChar(game.state[px][py])
+			if winner != nil && winner.id != p.id {
+				return nil, errors.New("both players have a winning line")
+			}
+			winner = &p
+		}
+	}
+
+	if winner != nil {
+		if winner.id == game.startPlayer {
+			return nil, errors.New("game was won by the player to move")
+		}
+
+		return winState.CreateWin(winState.Player{Id: winner.id, Char: int(winner.char)}), nil
+	}
+
+	if game.checkDraw() {
+		return winState.Values.Draw, nil
+	}
+
+	return winState.Values.None, nil
+}
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"GridPlay/game/winState"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePosition(t *testing.T) {
+	game, err := ParsePosition("x.o/.x./...", 3)
+	require.NoError(t, err)
+
+	require.Equal(t, 3, game.GetWidth())
+	require.Equal(t, 3, game.GetHeight())
+	require.Equal(t, 'x', game.GetBoard()[0][0])
+	require.Equal(t, 'o', game.GetBoard()[2][0])
+	require.Equal(t, 'x', game.GetBoard()[1][1])
+
+	// x has one piece more, so o is to move.
+	player := game.GetCurrentRoundPlayer()
+	require.Equal(t, 'o', player.GetChar().GetRune())
+
+	require.NoError(t, game.Move(Pos{2, 2}))
+	player = game.GetCurrentRoundPlayer()
+	require.Equal(t, 'x', player.GetChar().GetRune())
+}
+
+func TestParsePositionOutcome(t *testing.T) {
+	game, err := ParsePosition("xxx/oo./...", 3)
+	require.NoError(t, err)
+	require.True(t, winState.IsWin(game.GetWinState()))
+	require.Error(t, game.Move(Pos{2, 2}))
+
+	game, err = ParsePosition("xox/xoo/oxx", 3)
+	require.NoError(t, err)
+	require.Equal(t, winState.Values.Draw, game.GetWinState())
+
+	game, err = ParsePosition("..../..../..../....", 4)
+	require.NoError(t, err)
+	require.Equal(t, winState.Values.None, game.GetWinState())
+}
+
+func TestParsePositionErrors(t *testing.T) {
+	for _, position := range []string{
+		"x../o",       // rows of different length
+		"xa./.../...", // unknown cell
+		"xx./.../...", // x has two pieces more
+		"o../.../...", // o moved first
+		"xxx/ooo/x..", // both have a line
+		"xxx/oo./o..", // x won, but it is x turn
+	} {
+		_, err := ParsePosition(position, 3)
+		require.Error(t, err, position)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, position := range []string{
+		"... x 0 3",
+		"x.o/.x./... o 3 3",
+		"xxx/oo./... o 5 3",
+		"xox/xoo/oxx o 9 3",
+		// o started.
+		"o../.../... x 1 3",
+		"...../.x.o./..... x 2 4",
+	} {
+		game, err := Parse(position)
+		require.NoError(t, err, position)
+		require.Equal(t, position, Format(game))
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, position := range []string{
+		"x../.../...",         // board only
+		"x../.../... o 1",     // no win length
+		"x../.../... o 1 a",   // win length is not a number
+		"x../.../... o 1 4",   // win length does not fit
+		"x../.../... - 1 3",   // unknown side
+		"x../.../... o 2 3",   // wrong move counter
+		"x../.../... x 1 3",   // x moved, but it is x turn again
+		"xx./.../... o 2 3",   // x has two pieces more
+		"xxx/oo./... x 5 3",   // x won, but it is x turn
+		"x../.../... o 1 3 1", // extra field
+	} {
+		_, err := Parse(position)
+		require.Error(t, err, position)
+	}
+}
+
+func TestFormatPlayedGame(t *testing.T) {
+	game := CreateGame()
+	require.True(t, strings.HasPrefix(Format(game), ".../.../... "))
+
+	require.NoError(t, game.Move(Pos{1, 1}))
+	require.NoError(t, game.Move(Pos{0, 2}))
+
+	first := game.GetPlayerWithId(0)
+	second := game.GetPlayerWithId(1)
+	expected := string([]rune{'.', '.', '.', '/', '.', first.char.GetRune(), '.', '/', second.char.GetRune(), '.', '.'})
+	require.Equal(t, expected+" "+string(first.char.GetRune())+" 2 3", Format(game))
+
+	parsed, err := Parse(Format(game))
+	require.NoError(t, err)
+	require.Equal(t, game.GetBoard(), parsed.GetBoard())
+	require.Equal(t, Format(game), Format(parsed))
+}
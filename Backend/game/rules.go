@@ -0,0 +1,225 @@
+package game
+
+import (
+	"GridPlay/assert"
+	"GridPlay/game/winState"
+	"GridPlay/gameRules"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+const (
+	Name = "tictactoe"
+	GomokuName = "gomoku"
+)
+
+// Params of the m,n,k-game variant.
+const (
+	ParamWidth = "width"
+	ParamHeight = "height"
+	ParamWinLength = "winLength"
+)
+
+// Rules adapts Game to gameRules.GameRules.
+type Rules struct {
+	name string
+	game *Game
+}
+
+type State struct {
+	// Board[x][y], ' ' is an empty cell.
+	Board [][]rune `json:"board"`
+	Width int `json:"width"`
+	Height int `json:"height"`
+	WinLength int `json:"winLength"`
+	CurrentPlayer int `json:"currentPlayer"`
+}
+
+// CreateRules creates tic-tac-toe, 3x3 with 3 in a row by default.
+func CreateRules(params gameRules.Params) (gameRules.GameRules, error) {
+	return createRules(Name, params, DefaultSize, DefaultSize)
+}
+
+// CreateGomokuRules creates five in a row on 15x15 board by default.
+func CreateGomokuRules(params gameRules.Params) (gameRules.GameRules, error) {
+	return createRules(GomokuName, params, 15, 5)
+}
+
+func createRules(name string, params gameRules.Params, defSize, defWinLength int) (gameRules.GameRules, error) {
+	game, err := CreateCustomGame(
+		params.Get(ParamWidth, defSize),
+		params.Get(ParamHeight, defSize),
+		params.Get(ParamWinLength, defWinLength),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rules{
+		name: name,
+		game: game,
+	}, nil
+}
+
+func (rules *Rules) GetName() string {
+	return rules.name
+}
+
+func (rules *Rules) GetParams() gameRules.Params {
+	assert.NotNil(rules.game, "game was nil")
+
+	return gameRules.Params{
+		ParamWidth: rules.game.GetWidth(),
+		ParamHeight: rules.game.GetHeight(),
+		ParamWinLength: rules.game.GetWinLength(),
+	}
+}
+
+func (rules *Rules) DecodeMove(data []byte) (gameRules.Move, error) {
+	var pos Pos
+	err := json.Unmarshal(data, &pos)
+
+	if err != nil {
+		return nil, errors.New("move must be a position")
+	}
+
+	return pos, nil
+}
+
+func (rules *Rules) ValidateMove(playerId int, move gameRules.Move) error {
+	assert.NotNil(rules.game, "game was nil")
+
+	pos, ok := move.(Pos)
+	assert.Assert(ok, "type assertion failed for move pos")
+
+	if rules.game.GetCurrentRoundPlayer().id != playerId {
+		return errors.New("not your round")
+	}
+
+	return rules.game.ValidateMove(pos)
+}
+
+func (rules *Rules) ApplyMove(playerId int, move gameRules.Move) (gameRules.MoveResult, error) {
+	err := rules.ValidateMove(playerId, move)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pos := move.(Pos)
+	err = rules.game.Move(pos)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pos, nil
+}
+
+func (rules *Rules) GetCurrentPlayer() int {
+	assert.NotNil(rules.game, "game was nil")
+
+	return rules.game.GetCurrentRoundPlayer().id
+}
+
+func (rules *Rules) GetOutcome() winState.WinState {
+	assert.NotNil(rules.game, "game was nil")
+
+	return rules.game.GetWinState()
+}
+
+func (rules *Rules) GetState() any {
+	assert.NotNil(rules.game, "game was nil")
+
+	return State{
+		Board: rules.game.GetBoard(),
+		Width: rules.game.GetWidth(),
+		Height: rules.game.GetHeight(),
+		WinLength: rules.game.GetWinLength(),
+		CurrentPlayer: rules.GetCurrentPlayer(),
+	}
+}
+
+func (rules *Rules) GetPlayerSymbol(playerId int) rune {
+	assert.NotNil(rules.game, "game was nil")
+
+	player := rules.game.GetPlayerWithId(playerId)
+	return player.GetChar().GetRune()
+}
+
+func (rules *Rules) SwapSymbols() {
+	assert.NotNil(rules.game, "game was nil")
+
+	rules.game.SwapChars()
+}
+
+func (rules *Rules) GetMoveNumber() int {
+	assert.NotNil(rules.game, "game was nil")
+
+	return rules.game.GetMoveNumber()
+}
+
+func (rules *Rules) Clone() gameRules.Searchable {
+	assert.NotNil(rules.game, "game was nil")
+
+	return &Rules{
+		name: rules.name,
+		game: rules.game.Clone(),
+	}
+}
+
+func (rules *Rules) GetLegalMoves() []gameRules.Move {
+	assert.NotNil(rules.game, "game was nil")
+
+	cells := rules.game.GetEmptyCells()
+	moves := make([]gameRules.Move, len(cells))
+
+	for i, pos := range cells {
+		moves[i] = pos
+	}
+
+	return moves
+}
+
+func (rules *Rules) UndoMove() error {
+	assert.NotNil(rules.game, "game was nil")
+
+	return rules.game.Undo()
+}
+
+func (rules *Rules) GetMoveCount() int {
+	assert.NotNil(rules.game, "game was nil")
+
+	return rules.game.GetMoveCount()
+}
+
+// LoadPosition replaces the game with a position in Parse notation. Board alone,
+// as accepted by ParsePosition, is also accepted and keeps the current win length.
+func (rules *Rules) LoadPosition(text string) error {
+	assert.NotNil(rules.game, "game was nil")
+
+	var game *Game
+	var err error
+
+	if strings.ContainsRune(text, ' ') {
+		game, err = Parse(text)
+	} else {
+		game, err = ParsePosition(text, rules.game.GetWinLength())
+	}
+
+	if err != nil {
+		return err
+	}
+
+	rules.game = game
+	return nil
+}
+
+// FormatPosition returns the position in Parse notation.
+func (rules *Rules) FormatPosition() string {
+	assert.NotNil(rules.game, "game was nil")
+
+	return Format(rules.game)
+}
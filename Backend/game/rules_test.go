@@ -0,0 +1,100 @@
+package game
+
+import (
+	"GridPlay/gameRules"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesMove(t *testing.T) {
+	rules, err := CreateRules(nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, rules.GetCurrentPlayer())
+
+	move, err := rules.DecodeMove([]byte(`{"x":1,"y":2}`))
+	require.NoError(t, err)
+	require.Equal(t, Pos{1, 2}, move)
+
+	require.Error(t, rules.ValidateMove(1, move))
+
+	result, err := rules.ApplyMove(0, move)
+	require.NoError(t, err)
+	require.Equal(t, Pos{1, 2}, result)
+	require.Equal(t, 1, rules.GetCurrentPlayer())
+
+	_, err = rules.ApplyMove(1, move)
+	require.Error(t, err)
+
+	outOfRange, err := rules.DecodeMove([]byte(`{"x":3,"y":0}`))
+	require.NoError(t, err)
+	require.Error(t, rules.ValidateMove(1, outOfRange))
+
+	_, err = rules.DecodeMove([]byte(`"x"`))
+	require.Error(t, err)
+}
+
+func TestRulesParams(t *testing.T) {
+	rules, err := CreateRules(gameRules.Params{ParamWidth: 4, ParamHeight: 4, ParamWinLength: 4})
+	require.NoError(t, err)
+	require.Equal(t, Name, rules.GetName())
+
+	state := rules.GetState().(State)
+	require.Equal(t, 4, state.Width)
+	require.Equal(t, 4, state.Height)
+	require.Equal(t, 4, state.WinLength)
+	require.Len(t, state.Board, 4)
+
+	gomoku, err := CreateGomokuRules(nil)
+	require.NoError(t, err)
+	require.Equal(t, GomokuName, gomoku.GetName())
+	require.Equal(t, 15, gomoku.GetState().(State).Width)
+
+	_, err = CreateRules(gameRules.Params{ParamWinLength: 5})
+	require.Error(t, err)
+
+	defaults, err := CreateRules(nil)
+	require.NoError(t, err)
+	explicit, err := CreateRules(gameRules.Params{ParamWidth: 3})
+	require.NoError(t, err)
+	require.Equal(t, gameRules.Params{ParamWidth: 3, ParamHeight: 3, ParamWinLength: 3}, defaults.(gameRules.Parametrized).GetParams())
+	require.Equal(t, defaults.(gameRules.Parametrized).GetParams(), explicit.(gameRules.Parametrized).GetParams())
+}
+
+func TestRulesLoadPosition(t *testing.T) {
+	rules, err := CreateGomokuRules(nil)
+	require.NoError(t, err)
+	loader := rules.(*Rules)
+
+	// Board alone keeps gomoku win length.
+	require.NoError(t, loader.LoadPosition("xx.../o..../....."))
+	require.Equal(t, "xx.../o..../..... o 3 5", loader.FormatPosition())
+
+	require.NoError(t, loader.LoadPosition("xx./oo./... x 4 3"))
+	require.Equal(t, "xx./oo./... x 4 3", loader.FormatPosition())
+	require.Equal(t, 0, rules.GetCurrentPlayer())
+
+	require.Error(t, loader.LoadPosition("xx./o../... x 3 3"))
+}
+
+func TestRulesGameState(t *testing.T) {
+	rules, err := CreateRules(nil)
+	require.NoError(t, err)
+
+	state := gameRules.GetGameState(rules)
+	require.Equal(t, Name, state.Game)
+	require.Equal(t, 0, state.MoveNumber)
+	require.Equal(t, gameRules.Outcome{Status: "none", Winner: -1}, state.Outcome)
+
+	require.NoError(t, rules.(*Rules).LoadPosition("xx./oo./... x 4 3"))
+
+	_, err = rules.ApplyMove(0, Pos{2, 0})
+	require.NoError(t, err)
+
+	state = gameRules.GetGameState(rules)
+	require.Equal(t, 5, state.MoveNumber)
+	require.Equal(t, 1, state.CurrentPlayer)
+	require.Equal(t, [2]rune{'x', 'o'}, state.Symbols)
+	require.Equal(t, gameRules.Outcome{Status: "win", Winner: 0}, state.Outcome)
+	require.Equal(t, rules.GetState(), state.State)
+}
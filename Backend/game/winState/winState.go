@@ -34,4 +34,27 @@ var (
 		Draw: &draw{},
 		Win: &win{},
 	}
-)
\ No newline at end of file
+)
+
+// CreateWin creates win state of the player. Values.Win is shared
+// between games, so every finished game needs its own win.
+func CreateWin(player Player) WinState {
+	return &win{Player: player}
+}
+
+func IsWin(ws WinState) bool {
+	_, ok := ws.(*win)
+	return ok
+}
+
+// Status returns "none", "draw" or "win".
+func Status(ws WinState) string {
+	switch ws.(type) {
+	case *draw:
+		return "draw"
+	case *win:
+		return "win"
+	default:
+		return "none"
+	}
+}
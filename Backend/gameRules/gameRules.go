@@ -0,0 +1,113 @@
+package gameRules
+
+import "GridPlay/game/winState"
+
+// Move is a game specific move, decoded from client message data.
+type Move any
+
+// MoveResult is a game specific, json serializable description of an applied move.
+// It is sent to both players after the move was accepted.
+type MoveResult any
+
+// Params are variant parameters of a game, e.g. board size.
+type Params map[string]int
+
+type GameRules interface {
+	GetName() string
+	DecodeMove(data []byte) (Move, error)
+	ValidateMove(playerId int, move Move) error
+	ApplyMove(playerId int, move Move) (MoveResult, error)
+	GetCurrentPlayer() int
+	GetOutcome() winState.WinState
+	GetState() any
+	GetPlayerSymbol(playerId int) rune
+	// GetMoveNumber returns number of moves played so far.
+	GetMoveNumber() int
+}
+
+// GameState is a complete snapshot of a game, clients can redraw everything from it.
+type GameState struct {
+	Game string `json:"game"`
+	// State is game specific, see GetState.
+	State any `json:"state"`
+	// Symbols of players by id.
+	Symbols [2]rune `json:"symbols"`
+	CurrentPlayer int `json:"currentPlayer"`
+	MoveNumber int `json:"moveNumber"`
+	Outcome Outcome `json:"outcome"`
+}
+
+// Outcome Status is "none", "win" or "draw", Winner is -1 unless the game was won.
+type Outcome struct {
+	Status string `json:"status"`
+	Winner int `json:"winner"`
+}
+
+// GetGameState takes a snapshot of the game.
+func GetGameState(rules GameRules) GameState {
+	return GameState{
+		Game: rules.GetName(),
+		State: rules.GetState(),
+		Symbols: [2]rune{rules.GetPlayerSymbol(0), rules.GetPlayerSymbol(1)},
+		CurrentPlayer: rules.GetCurrentPlayer(),
+		MoveNumber: rules.GetMoveNumber(),
+		Outcome: getOutcome(rules.GetOutcome()),
+	}
+}
+
+func getOutcome(outcome winState.WinState) Outcome {
+	if winState.IsWin(outcome) {
+		return Outcome{Status: "win", Winner: outcome.GetPlayer().Id}
+	}
+
+	if outcome == winState.Values.Draw {
+		return Outcome{Status: "draw", Winner: -1}
+	}
+
+	return Outcome{Status: "none", Winner: -1}
+}
+
+// Get returns value of the param or def if it was not set.
+func (params Params) Get(name string, def int) int {
+	value, ok := params[name]
+
+	if !ok {
+		return def
+	}
+
+	return value
+}
+
+// Searchable games can be explored by engines, e.g. bots.
+type Searchable interface {
+	GameRules
+	// Clone returns independent copy of the game.
+	Clone() Searchable
+	// GetLegalMoves returns all legal moves of current player.
+	GetLegalMoves() []Move
+}
+
+// Parametrized games report every param they use, defaults included,
+// so games created with equal params can be recognized.
+type Parametrized interface {
+	GetParams() Params
+}
+
+// PositionLoader games can be set up from a position in text notation.
+type PositionLoader interface {
+	LoadPosition(text string) error
+}
+
+// SymbolSwapper games can exchange symbols of players before the first move,
+// e.g. so players of a rematch do not keep their symbols.
+type SymbolSwapper interface {
+	SwapSymbols()
+}
+
+// Undoable games can take back moves, e.g. for take-back requests.
+type Undoable interface {
+	// UndoMove takes back the last move.
+	UndoMove() error
+	// GetMoveCount returns number of moves that can be taken back.
+	GetMoveCount() int
+}
@@ -0,0 +1,92 @@
+package gameRules
+
+import (
+	"GridPlay/assert"
+	"errors"
+	"sort"
+	"sync"
+)
+
+type Factory func(params Params) (GameRules, error)
+
+type Registry struct {
+	factories map[string]Factory
+	defaultGame string
+	mut sync.Mutex
+}
+
+func CreateRegistry() *Registry {
+	return &Registry{
+		factories: make(map[string]Factory),
+	}
+}
+
+func (registry *Registry) Register(name string, factory Factory) {
+	assert.NotNil(factory, "factory was nil")
+
+	registry.mut.Lock()
+	defer registry.mut.Unlock()
+
+	_, exists := registry.factories[name]
+	assert.Assert(!exists, "game was already registered", "game", name)
+
+	registry.factories[name] = factory
+}
+
+func (registry *Registry) SetDefault(name string) {
+	registry.mut.Lock()
+	defer registry.mut.Unlock()
+
+	_, exists := registry.factories[name]
+	assert.Assert(exists, "default game must be registered", "game", name)
+
+	registry.defaultGame = name
+}
+
+func (registry *Registry) GetDefault() string {
+	registry.mut.Lock()
+	defer registry.mut.Unlock()
+
+	assert.Assert(registry.defaultGame != "", "default game was not set")
+
+	return registry.defaultGame
+}
+
+func (registry *Registry) Has(name string) bool {
+	registry.mut.Lock()
+	defer registry.mut.Unlock()
+
+	_, exists := registry.factories[name]
+	return exists
+}
+
+func (registry *Registry) GetNames() []string {
+	registry.mut.Lock()
+	defer registry.mut.Unlock()
+
+	names := make([]string, 0, len(registry.factories))
+	for name := range registry.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (registry *Registry) Create(name string, params Params) (GameRules, error) {
+	registry.mut.Lock()
+	factory, exists := registry.factories[name]
+	registry.mut.Unlock()
+
+	if !exists {
+		return nil, errors.New("unknown game type")
+	}
+
+	rules, err := factory(params)
+	if err != nil {
+		return nil, err
+	}
+
+	assert.NotNil(rules, "factory returned nil rules")
+	return rules, nil
+}
@@ -0,0 +1,63 @@
+package gameRules
+
+import (
+	"GridPlay/game/winState"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type stubRules struct {
+	params Params
+}
+
+func (rules *stubRules) GetName() string { return "stub" }
+func (rules *stubRules) DecodeMove(data []byte) (Move, error) { return nil, nil }
+func (rules *stubRules) ValidateMove(playerId int, move Move) error { return nil }
+func (rules *stubRules) ApplyMove(playerId int, move Move) (MoveResult, error) { return nil, nil }
+func (rules *stubRules) GetCurrentPlayer() int { return 0 }
+func (rules *stubRules) GetOutcome() winState.WinState { return winState.Values.None }
+func (rules *stubRules) GetState() any { return nil }
+func (rules *stubRules) GetPlayerSymbol(playerId int) rune { return 's' }
+func (rules *stubRules) GetMoveNumber() int { return 0 }
+
+func createStub(params Params) (GameRules, error) {
+	if params.Get("size", 1) < 1 {
+		return nil, errors.New("size must be positive")
+	}
+
+	return &stubRules{params: params}, nil
+}
+
+func TestRegistryCreate(t *testing.T) {
+	registry := CreateRegistry()
+	registry.Register("stub", createStub)
+	registry.SetDefault("stub")
+
+	require.True(t, registry.Has("stub"))
+	require.Equal(t, "stub", registry.GetDefault())
+	require.Equal(t, []string{"stub"}, registry.GetNames())
+
+	rules, err := registry.Create("stub", Params{"size": 3})
+	require.NoError(t, err)
+	require.Equal(t, 3, rules.(*stubRules).params.Get("size", 0))
+}
+
+func TestRegistryCreateErrors(t *testing.T) {
+	registry := CreateRegistry()
+	registry.Register("stub", createStub)
+
+	_, err := registry.Create("unknown", nil)
+	require.Error(t, err)
+
+	_, err = registry.Create("stub", Params{"size": 0})
+	require.Error(t, err)
+}
+
+func TestParamsGet(t *testing.T) {
+	var params Params
+
+	require.Equal(t, 7, params.Get("width", 7))
+	require.Equal(t, 2, Params{"width": 2}.Get("width", 7))
+}
@@ -0,0 +1,96 @@
+package gameServer
+
+import (
+	"GridPlay/analysis"
+	"GridPlay/assert"
+	"GridPlay/gameRules"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+const (
+	// MaxAnalysisMoves limits size of analysed positions, 15x15 board for m,n,k-games.
+	MaxAnalysisMoves = 225
+	// MaxConcurrentAnalyses is the number of positions analysed at once, other requests are refused.
+	MaxConcurrentAnalyses = 2
+
+	maxAnalysisRequestSize = 16 * 1024
+)
+
+type AnalysisRequest struct {
+	Game string `json:"game"`
+	Params gameRules.Params `json:"params"`
+	Position string `json:"position"`
+}
+
+// HandleAnalysis answers POST request with AnalysisRequest body with analysis.Analysis of the position.
+func (srv *Server) HandleAnalysis(w http.ResponseWriter, r *http.Request) error {
+	assert.NotNil(srv.games, "game registry was nil")
+	assert.NotNil(srv.analyses, "analysis slots were nil")
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method == http.MethodOptions {
+		return nil
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "analysis must be requested with POST", http.StatusMethodNotAllowed)
+		return errors.New("wrong analysis request method")
+	}
+
+	var request AnalysisRequest
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAnalysisRequestSize)).Decode(&request)
+	if err != nil {
+		http.Error(w, "cannot decode analysis request", http.StatusBadRequest)
+		return err
+	}
+
+	state, err := srv.loadPosition(request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	select {
+	case srv.analyses <- struct{}{}:
+		defer func() { <-srv.analyses }()
+	default:
+		http.Error(w, "server is busy analysing other positions", http.StatusServiceUnavailable)
+		return errors.New("too many analyses at once")
+	}
+
+	slog.Debug("analysing position", "game", request.Game, "position", request.Position)
+	result := analysis.Analyze(state, analysis.DefaultConfig())
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(result)
+}
+
+func (srv *Server) loadPosition(request AnalysisRequest) (gameRules.Searchable, error) {
+	rules, err := srv.games.Create(request.Game, request.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	loader, ok := rules.(gameRules.PositionLoader)
+	searchable, searchableOk := rules.(gameRules.Searchable)
+
+	if !ok || !searchableOk {
+		return nil, errors.New("game does not support analysis")
+	}
+
+	err = loader.LoadPosition(request.Position)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(searchable.GetLegalMoves()) > MaxAnalysisMoves {
+		return nil, errors.New("position is too big to be analysed")
+	}
+
+	return searchable, nil
+}
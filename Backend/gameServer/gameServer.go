@@ -2,21 +2,63 @@ package gameServer
 
 import (
 	"GridPlay/assert"
+	"GridPlay/engine"
+	"GridPlay/gameRules"
 	"GridPlay/gameServer/internal/connection"
+	"GridPlay/gameServer/internal/handlers"
+	"GridPlay/gameServer/internal/matchLog"
 	"GridPlay/gameServer/internal/server/mediator"
+	"GridPlay/rating"
+	"GridPlay/record"
+	"GridPlay/store"
+	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 )
 
+const DefaultBotDifficulty = engine.Greedy
+
+// DefaultPlayerName is shown for players who connect without a name, their games are not ranked.
+const DefaultPlayerName = handlers.DefaultPlayerName
+
+const maxPlayerNameLength = 24
+
+// TimeControl is either a fixed time for every move, or total time of a player plus increment per move.
+type TimeControl = handlers.TimeControl
+
 type Server struct {
 	srvMediator *mediator.ServerMediator
+	games *gameRules.Registry
+	records *store.Store[*record.Record]
+	logs *store.Store[*matchLog.Log]
+	ratings *rating.Ratings
+	names *rating.Names
+	// Holds a value for every analysis in progress.
+	analyses chan struct{}
 }
 
 func InitGameServer() *Server {
+	games := gameRules.CreateRegistry()
+	registerGames(games)
+
+	records := store.CreateStore[*record.Record](store.DefaultSize)
+	logs := store.CreateStore[*matchLog.Log](store.DefaultSize)
+	ratings := rating.CreateRatings(rating.CreateGlicko2(rating.DefaultTau))
+
 	srv := &Server{
-		srvMediator: mediator.CreateServerMediator(),
+		srvMediator: mediator.CreateServerMediator(games, records, logs, ratings),
+		games: games,
+		records: records,
+		logs: logs,
+		ratings: ratings,
+		names: rating.CreateNames(),
+		analyses: make(chan struct{}, MaxConcurrentAnalyses),
 	}
 
 	return srv
@@ -24,6 +66,43 @@ func InitGameServer() *Server {
 
 func (srv *Server) HandleConnection(w http.ResponseWriter, r *http.Request) error {
 	assert.NotNil(srv.srvMediator, "mediator was nil")
+	assert.NotNil(srv.names, "names was nil")
+
+	botDifficulty, err := parseBotDifficulty(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	spectate, isSpectator, err := parseSpectate(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	if isSpectator && !srv.srvMediator.HasRoom(spectate) {
+		http.Error(w, "room does not exist", http.StatusNotFound)
+		return errors.New("room to spectate does not exist")
+	}
+
+	name := parseName(r)
+
+	// Players prove their name with "token" query parameter, the token is given by HandleNames.
+	token := r.URL.Query().Get("token")
+	verified := token != "" && srv.names.Verify(name, token)
+
+	if token != "" && !verified {
+		http.Error(w, "token does not match the name", http.StatusForbidden)
+		return errors.New("token does not match the name")
+	}
+
+	// Players reconnect to their seat with "resume" query parameter, the token is sent at match start.
+	resumeToken := r.URL.Query().Get("resume")
+
+	if resumeToken != "" && !srv.srvMediator.HasResumeToken(resumeToken) {
+		http.Error(w, "resume token is not valid", http.StatusNotFound)
+		return errors.New("resume token is not valid")
+	}
 
 	slog.Debug("creating socket")
 
@@ -34,13 +113,98 @@ func (srv *Server) HandleConnection(w http.ResponseWriter, r *http.Request) erro
         return err
     }
 
-	slog.Debug("adding socket as connection")
 	conn := connection.CreateConnection(socket)
-	srv.srvMediator.AddConnection(conn)
+
+	if isSpectator {
+		slog.Debug("adding socket as spectator")
+		err = srv.srvMediator.AddSpectator(conn, spectate)
+
+		if err != nil {
+			socket.Close()
+		}
+
+		return err
+	}
+
+	if resumeToken != "" {
+		slog.Debug("adding socket as resumed connection")
+		err = srv.srvMediator.ResumeConnection(conn, resumeToken, name)
+
+		if err != nil {
+			socket.Close()
+		}
+
+		return err
+	}
+
+	slog.Debug("adding socket as connection")
+	srv.srvMediator.AddConnection(conn, botDifficulty, name, verified, parseEnqueue(r))
 
 	return nil
 }
 
+// Spectators join a room with "spectate" query parameter, e.g. /ws?spectate=<room uuid>.
+func parseSpectate(r *http.Request) (uuid.UUID, bool, error) {
+	text := r.URL.Query().Get("spectate")
+
+	if text == "" {
+		return uuid.Nil, false, nil
+	}
+
+	id, err := uuid.Parse(text)
+	if err != nil {
+		return uuid.Nil, false, errors.New("spectate must be a room uuid")
+	}
+
+	return id, true, nil
+}
+
+// Players choose their name with "name" query parameter, e.g. /ws?name=alice.
+// It is cut to 24 characters.
+func parseName(r *http.Request) string {
+	return normalizeName(r.URL.Query().Get("name"))
+}
+
+func normalizeName(text string) string {
+	name := []rune(strings.TrimSpace(text))
+
+	if len(name) == 0 {
+		return DefaultPlayerName
+	}
+
+	if len(name) > maxPlayerNameLength {
+		name = name[:maxPlayerNameLength]
+	}
+
+	return string(name)
+}
+
+// Players who choose the game later connect with /ws?enqueue=false, the rest wait for the default game.
+func parseEnqueue(r *http.Request) bool {
+	return r.URL.Query().Get("enqueue") != "false"
+}
+
+// HandleRooms answers with the list of live rooms.
+func (srv *Server) HandleRooms(w http.ResponseWriter, r *http.Request) error {
+	assert.NotNil(srv.srvMediator, "mediator was nil")
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Content-Type", "application/json")
+
+	return json.NewEncoder(w).Encode(srv.srvMediator.GetRoomInfos())
+}
+
+// Players choose bot difficulty with "bot" query parameter, e.g. /ws?bot=random.
+func parseBotDifficulty(r *http.Request) (engine.Difficulty, error) {
+	name := r.URL.Query().Get("bot")
+
+	if name == "" {
+		return DefaultBotDifficulty, nil
+	}
+
+	return engine.ParseDifficulty(name)
+}
+
 var upgrader = websocket.Upgrader {
 	ReadBufferSize:  2048,
 	WriteBufferSize: 2048,
@@ -49,6 +213,43 @@ var upgrader = websocket.Upgrader {
 	},
 }
 
+// SetReconnectGrace sets for how long new rooms hold seats of disconnected players, 0 disables resuming.
+func (srv *Server) SetReconnectGrace(grace time.Duration) {
+	assert.NotNil(srv.srvMediator, "mediator was nil")
+
+	srv.srvMediator.SetReconnectGrace(grace)
+}
+
+// SetInviteTimeout sets for how long invite codes of private rooms are valid, the default is 5 minutes.
+func (srv *Server) SetInviteTimeout(timeout time.Duration) {
+	assert.NotNil(srv.srvMediator, "mediator was nil")
+
+	srv.srvMediator.SetInviteTimeout(timeout)
+}
+
+// SetTimeControl sets time control of new matches of the game, players who run out of time lose.
+func (srv *Server) SetTimeControl(game string, control TimeControl) {
+	assert.NotNil(srv.srvMediator, "mediator was nil")
+
+	srv.srvMediator.SetTimeControl(game, control)
+}
+
+// SetBestOf makes new matches of the game a series of games, the players switch sides after every game.
+// The match ends when a player clinches the series.
+func (srv *Server) SetBestOf(game string, games int) {
+	assert.NotNil(srv.srvMediator, "mediator was nil")
+
+	srv.srvMediator.SetBestOf(game, games)
+}
+
+// SetRatingSystem sets how ranked games are rated, the default is Glicko-2.
+// Ratings of the players are kept, only new games are rated by the system.
+func (srv *Server) SetRatingSystem(system rating.System) {
+	assert.NotNil(srv.srvMediator, "mediator was nil")
+
+	srv.srvMediator.SetRatingSystem(system)
+}
+
 func (srv *Server) StartLoop() {
 	assert.NotNil(srv.srvMediator, "mediator was nil")
 
@@ -0,0 +1,23 @@
+package gameServer
+
+import (
+	"GridPlay/checkers"
+	"GridPlay/connectFour"
+	"GridPlay/game"
+	"GridPlay/gameRules"
+	"GridPlay/reversi"
+	"GridPlay/ultimate"
+)
+
+// registerGames adds all games that can be played on the server.
+// To add a new game, implement gameRules.GameRules and register its factory here.
+func registerGames(games *gameRules.Registry) {
+	games.Register(game.Name, game.CreateRules)
+	games.Register(game.GomokuName, game.CreateGomokuRules)
+	games.Register(connectFour.Name, connectFour.CreateRules)
+	games.Register(ultimate.Name, ultimate.CreateRules)
+	games.Register(reversi.Name, reversi.CreateRules)
+	games.Register(checkers.Name, checkers.CreateRules)
+
+	games.SetDefault(game.Name)
+}
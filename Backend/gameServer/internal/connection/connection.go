@@ -2,6 +2,7 @@ package connection
 
 import (
 	"log/slog"
+	"sync"
 
 	"GridPlay/assert"
 	"GridPlay/gameServer/message"
@@ -9,11 +10,15 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+// Connection can be written from several goroutines, e.g. by rooms of spectators and by its own loop,
+// websocket allows only one writer at a time.
 type Connection struct {
 	socket  *websocket.Conn
 	messageFromClient chan message.Message
 	exitChan chan bool
 	receives bool
+	// Guards writes to the socket and err.
+	mut sync.Mutex
 	err error
 }
 
@@ -40,22 +45,32 @@ func (conn *Connection) StopReceiving() {
 
 	if conn.receives {
 		closeMess := websocket.FormatCloseMessage(1000, "Connection closed by server.")
+
+		conn.mut.Lock()
 		conn.socket.WriteMessage(websocket.CloseMessage, closeMess)
+		conn.mut.Unlock()
 	}
 }
 
+// Close closes the socket without close message, receiving stops with an error.
+func (conn *Connection) Close() {
+	assert.NotNil(conn.socket, "websocket was nil")
+
+	conn.socket.Close()
+}
+
 func (conn *Connection) receiveMessages() {
 	assert.NotNil(conn.socket, "websocket was nil")
 
 	for {
-		if conn.err != nil {
+		if conn.GetLastError() != nil {
 			break
 		}
 
 		_, data, err := conn.socket.ReadMessage()
 		if err != nil {
 			slog.Info("connection closed with", "ip", conn.GetRemoteIP())
-			conn.err = err
+			conn.setError(err)
 			break;
 		}
 
@@ -77,11 +92,15 @@ func (conn *Connection) SendMessage(msg message.Message) bool {
 	assert.NotNil(msg, "msg was nil")
 	assert.NotNil(conn.socket, "websocket was nil")
 
+	data := msg.MarshalMessage()
+
+	conn.mut.Lock()
+	defer conn.mut.Unlock()
+
 	if conn.err != nil {
 		return false
 	}
 
-	data := msg.MarshalMessage()
 	conn.err = conn.socket.WriteMessage(websocket.TextMessage, data)
 
 	return true
@@ -90,6 +109,9 @@ func (conn *Connection) SendMessage(msg message.Message) bool {
 func (conn *Connection) SendPing() error {
 	assert.NotNil(conn.socket, "websocket was nil")
 
+	conn.mut.Lock()
+	defer conn.mut.Unlock()
+
 	if conn.err != nil {
 		return conn.err
 	}
@@ -112,5 +134,15 @@ func (conn *Connection) GetExitChan() <-chan bool {
 }
 
 func (conn *Connection) GetLastError() error {
+	conn.mut.Lock()
+	defer conn.mut.Unlock()
+
 	return conn.err
+}
+
+func (conn *Connection) setError(err error) {
+	conn.mut.Lock()
+	defer conn.mut.Unlock()
+
+	conn.err = err
 }
\ No newline at end of file
@@ -0,0 +1,56 @@
+package connection
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"GridPlay/gameServer/message"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentWrites(t *testing.T) {
+	sockets := make(chan *websocket.Conn, 1)
+	upgrader := websocket.Upgrader{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		socket, err := upgrader.Upgrade(w, r, nil)
+		if err == nil {
+			sockets <- socket
+		}
+	}))
+	defer srv.Close()
+
+	client, _, err := websocket.DefaultDialer.Dial("ws" + strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	defer client.Close()
+
+	conn := CreateConnection(<-sockets)
+	const writers, messages = 4, 100
+
+	var wg sync.WaitGroup
+	for range writers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range messages {
+				conn.SendMessage(message.MakeMessage(0, "hello"))
+				conn.SendPing()
+			}
+		}()
+	}
+
+	for range writers * messages {
+		_, _, err := client.ReadMessage()
+		require.NoError(t, err)
+	}
+
+	wg.Wait()
+	require.NoError(t, conn.GetLastError())
+}
@@ -19,8 +19,28 @@ const (
 	EventTypeRemoveRoom
 	EventTypeMove
 	EventTypeSendMessage
+	EventTypeTakeBackRequest
+	EventTypeTakeBackAnswer
+	EventTypeGameRecord
+	EventTypeSpectatorJoin
+	EventTypeSpectator
+	EventTypeResume
+	EventTypeGameStateRequest
+	EventTypeResign
+	EventTypeDrawOffer
+	EventTypeDrawAnswer
+	EventTypeRematchRequest
+	EventTypeRematchAnswer
+	EventTypeCreatePrivateRoom
+	EventTypeJoinPrivateRoom
+	EventTypeOpenRoom
+	EventTypeJoinRoom
+	EventTypeLobbyRequest
+	EventTypeLobbySubscribe
+	EventTypeEnqueue
 	// server
 	EventTypePlayersMatched
+	EventTypeBotMatched
 )
 
 func (eType EventType) String() string {
@@ -35,8 +55,48 @@ func (eType EventType) String() string {
 		return "Move"
 	case EventTypeSendMessage:
 		return "SendMessage"
+	case EventTypeTakeBackRequest:
+		return "TakeBackRequest"
+	case EventTypeTakeBackAnswer:
+		return "TakeBackAnswer"
+	case EventTypeGameRecord:
+		return "GameRecord"
+	case EventTypeSpectatorJoin:
+		return "SpectatorJoin"
+	case EventTypeSpectator:
+		return "Spectator"
+	case EventTypeResume:
+		return "Resume"
+	case EventTypeGameStateRequest:
+		return "GameStateRequest"
+	case EventTypeResign:
+		return "Resign"
+	case EventTypeDrawOffer:
+		return "DrawOffer"
+	case EventTypeDrawAnswer:
+		return "DrawAnswer"
+	case EventTypeRematchRequest:
+		return "RematchRequest"
+	case EventTypeRematchAnswer:
+		return "RematchAnswer"
+	case EventTypeCreatePrivateRoom:
+		return "CreatePrivateRoom"
+	case EventTypeJoinPrivateRoom:
+		return "JoinPrivateRoom"
+	case EventTypeOpenRoom:
+		return "OpenRoom"
+	case EventTypeJoinRoom:
+		return "JoinRoom"
+	case EventTypeLobbyRequest:
+		return "LobbyRequest"
+	case EventTypeLobbySubscribe:
+		return "LobbySubscribe"
+	case EventTypeEnqueue:
+		return "Enqueue"
 	case EventTypePlayersMatched:
 		return "PlayersMatched"
+	case EventTypeBotMatched:
+		return "BotMatched"
 	default:
 		assert.Never("unknown type of event", "server event", eType)
 		return "Unknown"
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"sync/atomic"
+
+	"GridPlay/assert"
+	"GridPlay/engine"
+	"GridPlay/game/winState"
+	"GridPlay/gameRules"
+	"GridPlay/gameServer/internal/event"
+	"GridPlay/gameServer/message/serverMsg"
+)
+
+// Bot plays on a room seat. It receives messages sent to its player
+// and emits EventMove through the player, same as a connection does.
+type Bot struct {
+	player *Player
+	rules gameRules.Searchable
+	createEngine engine.Factory
+	// Engine of the current game, search of a previous game can still run on its own engine.
+	engine engine.Engine
+	// Index of the game in the room, search started in a previous game is dropped.
+	game atomic.Int64
+	waitingForAnswer bool
+	gameEnded bool
+}
+
+func CreateBot(player *Player, rules gameRules.Searchable, createEngine engine.Factory) *Bot {
+	assert.NotNil(player, "player was nil")
+	assert.NotNil(rules, "game rules was nil")
+	assert.NotNil(createEngine, "engine factory was nil")
+
+	return &Bot{
+		player: player,
+		rules: rules,
+		createEngine: createEngine,
+		engine: createEngine(),
+	}
+}
+
+func (bot *Bot) Handle(e event.Event) {
+	eSendMessage, ok := e.(EventSendMessage)
+	assert.Assert(ok, "bot can only handle messages", "type", e.GetType())
+
+	msg := eSendMessage.Msg
+	slog.Debug("message in bot", "type", serverMsg.MsgType(msg.Type), "data", msg.Data)
+
+	switch serverMsg.MsgType(msg.Type) {
+	case serverMsg.TMoveAns:
+		bot.waitingForAnswer = false
+
+		moveRes, ok := msg.Data.(serverMsg.MoveRes)
+		if ok && !moveRes.Approved {
+			slog.Error("bot move rejected", "reason", moveRes.Reason)
+			return
+		}
+
+	case serverMsg.TTakeBackRequest:
+		// Move being searched is for the current position, it would be stale after take-back.
+		bot.player.Handle(EventTakeBackAnswer{
+			Accept: !bot.waitingForAnswer,
+		})
+		return
+
+	case serverMsg.TRematchRequest:
+		bot.player.Handle(EventRematchAnswer{
+			Accept: true,
+		})
+		return
+
+	case serverMsg.TDrawOffer:
+		bot.player.Handle(EventDrawAnswer{
+			Accept: false,
+		})
+		return
+
+	case serverMsg.TWinEvent:
+		// Game can end off the board, e.g. by resignation, rules do not know about it.
+		bot.gameEnded = true
+		return
+	}
+
+	bot.tryMove()
+}
+
+// reset prepares the bot for the next game in the room, e.g. rematch.
+// Search of the previous game may still run, so the next game gets a new engine.
+func (bot *Bot) reset(rules gameRules.Searchable, game int) {
+	assert.NotNil(rules, "game rules was nil")
+
+	bot.game.Store(int64(game))
+	bot.rules = rules
+	bot.engine = bot.createEngine()
+	bot.waitingForAnswer = false
+	bot.gameEnded = false
+}
+
+func (bot *Bot) tryMove() {
+	if bot.waitingForAnswer || bot.gameEnded || bot.rules.GetOutcome() != winState.Values.None {
+		return
+	}
+
+	if bot.rules.GetCurrentPlayer() != bot.player.playerID {
+		return
+	}
+
+	bot.waitingForAnswer = true
+
+	// Search can take a while, it works on a copy so the room is not blocked.
+	go bot.move(bot.engine, bot.rules.Clone(), int(bot.game.Load()))
+}
+
+func (bot *Bot) move(e engine.Engine, state gameRules.Searchable, game int) {
+	move := e.ChooseMove(state)
+	assert.NotNil(move, "bot has no legal move in unfinished game")
+
+	if int(bot.game.Load()) != game {
+		slog.Debug("bot dropped move of previous game", "game", game)
+		return
+	}
+
+	data, err := json.Marshal(move)
+	assert.NoError(err, "cannot marshal bot move")
+
+	// Next game can still start before the room gets the move, the room checks the game too.
+	bot.player.Handle(EventMove{
+		Data: data,
+		Game: game,
+	})
+}
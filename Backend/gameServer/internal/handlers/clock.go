@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"GridPlay/assert"
+	"time"
+)
+
+// TimeControl limits thinking time of players, zero value has no limit.
+type TimeControl struct {
+	// Total time of each player for the whole game, 0 means MoveTime is used.
+	Total time.Duration
+	// Increment is added to the player's time after each of its moves.
+	Increment time.Duration
+	// MoveTime limits every move when there is no Total time.
+	MoveTime time.Duration
+}
+
+func (control TimeControl) IsLimited() bool {
+	return control.Total > 0 || control.MoveTime > 0
+}
+
+// Clock is a chess clock, only time of the running player runs.
+type Clock struct {
+	control TimeControl
+	remaining [2]time.Duration
+	running int
+	// When time of the running player started to run.
+	since time.Time
+}
+
+func CreateClock(control TimeControl, running int, now time.Time) *Clock {
+	assert.Assert(control.IsLimited(), "clock needs limited time control")
+
+	clock := &Clock{
+		control: control,
+		running: running,
+		since: now,
+	}
+
+	for i := range clock.remaining {
+		clock.remaining[i] = control.Total
+
+		if control.Total == 0 {
+			clock.remaining[i] = control.MoveTime
+		}
+	}
+
+	return clock
+}
+
+// Switch stops time of the running player and starts time of next, e.g. after take-back.
+func (clock *Clock) Switch(next int, now time.Time) {
+	clock.remaining[clock.running] = clock.GetRemaining(clock.running, now)
+	clock.running = next
+	clock.since = now
+
+	if clock.control.Total == 0 {
+		clock.remaining[next] = clock.control.MoveTime
+	}
+}
+
+// Press is called after a move of the running player, it gets the increment.
+func (clock *Clock) Press(next int, now time.Time) {
+	player := clock.running
+
+	clock.Switch(next, now)
+	clock.remaining[player] += clock.control.Increment
+}
+
+func (clock *Clock) GetRemaining(player int, now time.Time) time.Duration {
+	remaining := clock.remaining[player]
+
+	if player == clock.running {
+		remaining -= now.Sub(clock.since)
+	}
+
+	return max(remaining, 0)
+}
+
+func (clock *Clock) GetRunning() int {
+	return clock.running
+}
+
+// IsFlagged returns true when the running player has no time left.
+func (clock *Clock) IsFlagged(now time.Time) bool {
+	return clock.GetRemaining(clock.running, now) == 0
+}
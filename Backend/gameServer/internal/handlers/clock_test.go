@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockIncrement(t *testing.T) {
+	start := time.Now()
+	clock := CreateClock(TimeControl{Total: time.Minute, Increment: 2 * time.Second}, 0, start)
+
+	now := start.Add(10 * time.Second)
+	require.Equal(t, 50 * time.Second, clock.GetRemaining(0, now))
+	require.Equal(t, time.Minute, clock.GetRemaining(1, now))
+
+	clock.Press(1, now)
+	require.Equal(t, 1, clock.GetRunning())
+	require.Equal(t, 52 * time.Second, clock.GetRemaining(0, now.Add(time.Hour)))
+
+	require.False(t, clock.IsFlagged(now.Add(59 * time.Second)))
+	require.True(t, clock.IsFlagged(now.Add(time.Minute)))
+	require.Equal(t, time.Duration(0), clock.GetRemaining(1, now.Add(time.Hour)))
+}
+
+func TestClockMoveTime(t *testing.T) {
+	start := time.Now()
+	clock := CreateClock(TimeControl{MoveTime: 5 * time.Second}, 0, start)
+
+	now := start.Add(4 * time.Second)
+	clock.Press(1, now)
+	now = now.Add(3 * time.Second)
+	clock.Press(0, now)
+
+	// Every move has the whole move time.
+	require.Equal(t, 5 * time.Second, clock.GetRemaining(0, now))
+	require.True(t, clock.IsFlagged(now.Add(5 * time.Second)))
+
+	clock.Switch(1, now.Add(time.Second))
+	require.Equal(t, 5 * time.Second, clock.GetRemaining(1, now.Add(time.Second)))
+}
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"GridPlay/assert"
+	"GridPlay/gameRules"
 	"GridPlay/gameServer/internal/event"
 	"GridPlay/gameServer/message"
 	"GridPlay/gameServer/message/clientMsg"
+	"GridPlay/record"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -20,9 +23,118 @@ type EventRemoveRoom struct {
 }
 
 type EventMove struct {
-	X int
-	Y int
+	Data []byte
 	Player *Player
+	// Index of the game in the room the move was chosen for, set only by bots.
+	Game int
+}
+
+type EventTakeBackRequest struct {
+	Player *Player
+}
+
+type EventTakeBackAnswer struct {
+	Accept bool
+	Player *Player
+}
+
+type EventResign struct {
+	Player *Player
+}
+
+type EventDrawOffer struct {
+	Player *Player
+}
+
+type EventDrawAnswer struct {
+	Accept bool
+	Player *Player
+}
+
+type EventRematchRequest struct {
+	Player *Player
+}
+
+type EventRematchAnswer struct {
+	Accept bool
+	Player *Player
+}
+
+// EventGameStateRequest asks for the complete game state, Player is nil for spectators.
+type EventGameStateRequest struct {
+	Player *Player
+}
+
+// EventGameRecord is sent by the room when its match has finished.
+// RecordUUID is the room uuid for the first game, later games of the room get new ones.
+type EventGameRecord struct {
+	RoomUUID uuid.UUID
+	RecordUUID uuid.UUID
+	Record *record.Record
+}
+
+// EventResume binds a new connection to the seat with the resume token.
+type EventResume struct {
+	Connection *PlayerConnection
+	Token string
+}
+
+type EventSpectatorJoin struct {
+	Connection *PlayerConnection
+}
+
+// EventSpectator wraps any event from a spectator connection.
+type EventSpectator struct {
+	Spectator *Spectator
+	Event event.Event
+}
+
+// GameChoice is the game a player wants to play, empty Game is the default one.
+// TimeControl without limit is the one set on the server for the game.
+type GameChoice struct {
+	Game string
+	Params gameRules.Params
+	TimeControl TimeControl
+	BestOf int
+}
+
+// EventEnqueue puts the player in the public queue of the game, it leaves the queue it was in.
+type EventEnqueue struct {
+	ConnectionId uuid.UUID
+	Choice GameChoice
+}
+
+// EventCreatePrivateRoom asks the server for an invite code, the player waits outside of the public queue.
+type EventCreatePrivateRoom struct {
+	ConnectionId uuid.UUID
+	Choice GameChoice
+}
+
+// EventJoinPrivateRoom seats the player in the private room with the invite code.
+type EventJoinPrivateRoom struct {
+	ConnectionId uuid.UUID
+	Code string
+}
+
+// EventOpenRoom lists a room in the lobby, the player waits outside of the public queue.
+type EventOpenRoom struct {
+	ConnectionId uuid.UUID
+	Choice GameChoice
+}
+
+// EventJoinRoom seats the player in an open room from the lobby, or lets it spectate a room in play.
+type EventJoinRoom struct {
+	ConnectionId uuid.UUID
+	RoomId string
+}
+
+type EventLobbyRequest struct {
+	ConnectionId uuid.UUID
+}
+
+type EventLobbySubscribe struct {
+	ConnectionId uuid.UUID
+	Subscribe bool
 }
 
 type EventSendMessage struct {
@@ -42,6 +154,63 @@ func (eType EventMove) GetType() event.EventType {
 func (eType EventSendMessage) GetType() event.EventType {
 	return event.EventTypeSendMessage;
 }
+func (eType EventGameRecord) GetType() event.EventType {
+	return event.EventTypeGameRecord;
+}
+func (eType EventResume) GetType() event.EventType {
+	return event.EventTypeResume;
+}
+func (eType EventSpectatorJoin) GetType() event.EventType {
+	return event.EventTypeSpectatorJoin;
+}
+func (eType EventSpectator) GetType() event.EventType {
+	return event.EventTypeSpectator;
+}
+func (eType EventTakeBackRequest) GetType() event.EventType {
+	return event.EventTypeTakeBackRequest;
+}
+func (eType EventTakeBackAnswer) GetType() event.EventType {
+	return event.EventTypeTakeBackAnswer;
+}
+func (eType EventGameStateRequest) GetType() event.EventType {
+	return event.EventTypeGameStateRequest;
+}
+func (eType EventResign) GetType() event.EventType {
+	return event.EventTypeResign;
+}
+func (eType EventDrawOffer) GetType() event.EventType {
+	return event.EventTypeDrawOffer;
+}
+func (eType EventDrawAnswer) GetType() event.EventType {
+	return event.EventTypeDrawAnswer;
+}
+func (eType EventRematchRequest) GetType() event.EventType {
+	return event.EventTypeRematchRequest;
+}
+func (eType EventRematchAnswer) GetType() event.EventType {
+	return event.EventTypeRematchAnswer;
+}
+func (eType EventCreatePrivateRoom) GetType() event.EventType {
+	return event.EventTypeCreatePrivateRoom;
+}
+func (eType EventJoinPrivateRoom) GetType() event.EventType {
+	return event.EventTypeJoinPrivateRoom;
+}
+func (eType EventEnqueue) GetType() event.EventType {
+	return event.EventTypeEnqueue;
+}
+func (eType EventOpenRoom) GetType() event.EventType {
+	return event.EventTypeOpenRoom;
+}
+func (eType EventJoinRoom) GetType() event.EventType {
+	return event.EventTypeJoinRoom;
+}
+func (eType EventLobbyRequest) GetType() event.EventType {
+	return event.EventTypeLobbyRequest;
+}
+func (eType EventLobbySubscribe) GetType() event.EventType {
+	return event.EventTypeLobbySubscribe;
+}
 
 func EventFromClientMessage(msg message.Message) (event.Event, error) {
 	assert.NotNil(msg, "message was nil")
@@ -54,11 +223,134 @@ func EventFromClientMessage(msg message.Message) (event.Event, error) {
 		}
 
 		return EventMove{
-			X: moveMsg.X,
-			Y: moveMsg.Y,
+			Data: moveMsg,
+		}, nil
+
+	case clientMsg.TTakeBackRequest:
+		return EventTakeBackRequest{}, nil
+
+	case clientMsg.TTakeBackAnswer:
+		answerMsg, err := message.GetConcreteMessage[clientMsg.TakeBackAnswer](msg)
+		if err != nil {
+			return nil, err
+		}
+
+		return EventTakeBackAnswer{
+			Accept: answerMsg.Accept,
+		}, nil
+
+	case clientMsg.TGameStateRequest:
+		return EventGameStateRequest{}, nil
+
+	case clientMsg.TResign:
+		return EventResign{}, nil
+
+	case clientMsg.TOfferDraw:
+		return EventDrawOffer{}, nil
+
+	case clientMsg.TAcceptDraw:
+		return EventDrawAnswer{Accept: true}, nil
+
+	case clientMsg.TDeclineDraw:
+		return EventDrawAnswer{Accept: false}, nil
+
+	case clientMsg.TRematchRequest:
+		return EventRematchRequest{}, nil
+
+	case clientMsg.TRematchAnswer:
+		answerMsg, err := message.GetConcreteMessage[clientMsg.RematchAnswer](msg)
+		if err != nil {
+			return nil, err
+		}
+
+		return EventRematchAnswer{
+			Accept: answerMsg.Accept,
+		}, nil
+
+	case clientMsg.TCreatePrivateRoom:
+		choice, err := gameChoiceFromMessage(msg)
+		if err != nil {
+			return nil, err
+		}
+
+		return EventCreatePrivateRoom{
+			Choice: choice,
+		}, nil
+
+	case clientMsg.TJoinPrivateRoom:
+		joinMsg, err := message.GetConcreteMessage[clientMsg.JoinPrivateRoom](msg)
+		if err != nil {
+			return nil, err
+		}
+
+		return EventJoinPrivateRoom{
+			Code: joinMsg.Code,
+		}, nil
+
+	case clientMsg.TOpenRoom:
+		choice, err := gameChoiceFromMessage(msg)
+		if err != nil {
+			return nil, err
+		}
+
+		return EventOpenRoom{
+			Choice: choice,
+		}, nil
+
+	case clientMsg.TEnqueue:
+		choice, err := gameChoiceFromMessage(msg)
+		if err != nil {
+			return nil, err
+		}
+
+		return EventEnqueue{
+			Choice: choice,
+		}, nil
+
+	case clientMsg.TJoinRoom:
+		joinMsg, err := message.GetConcreteMessage[clientMsg.JoinRoom](msg)
+		if err != nil {
+			return nil, err
+		}
+
+		return EventJoinRoom{
+			RoomId: joinMsg.ID,
+		}, nil
+
+	case clientMsg.TLobbyRequest:
+		return EventLobbyRequest{}, nil
+
+	case clientMsg.TLobbySubscribe:
+		subscribeMsg, err := message.GetConcreteMessage[clientMsg.LobbySubscribe](msg)
+		if err != nil {
+			return nil, err
+		}
+
+		return EventLobbySubscribe{
+			Subscribe: subscribeMsg.Subscribe,
 		}, nil
 
 	default:
 		return nil, errors.New("this message has no corresponding event")
 	}
-}
\ No newline at end of file
+}
+
+func gameChoiceFromMessage(msg message.Message) (GameChoice, error) {
+	choiceMsg, err := message.GetConcreteMessage[clientMsg.GameChoice](msg)
+	if err != nil {
+		return GameChoice{}, err
+	}
+
+	control := choiceMsg.TimeControl
+
+	return GameChoice{
+		Game: choiceMsg.Game,
+		Params: choiceMsg.Params,
+		TimeControl: TimeControl{
+			Total: time.Duration(control.Total) * time.Second,
+			Increment: time.Duration(control.Increment) * time.Second,
+			MoveTime: time.Duration(control.MoveTime) * time.Second,
+		},
+		BestOf: choiceMsg.BestOf,
+	}, nil
+}
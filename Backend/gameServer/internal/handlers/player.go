@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -11,8 +12,18 @@ import (
 
 type Player struct {
 	nextHandler Handler
+	// Handles messages sent to this player instead of the connection, used by bots.
+	client Handler
 	connectionID uuid.UUID
+	// Nil for bots.
+	pConn *PlayerConnection
+	// Name chosen when the player took the seat, reconnecting does not change it.
+	name string
 	playerID int
+	// Zero while the player is connected.
+	disconnectedAt time.Time
+	// Points of games played in the room, a win is 1 and a draw 0.5.
+	score float64
 }
 
 func CreatePlayer(nextHandler Handler, connId uuid.UUID, playerId int) *Player {
@@ -29,6 +40,48 @@ func CreatePlayer(nextHandler Handler, connId uuid.UUID, playerId int) *Player {
 	}
 }
 
+func (player *Player) SetClient(client Handler) {
+	assert.NotNil(client, "client was nil")
+
+	player.client = client
+}
+
+func (player *Player) IsBot() bool {
+	return player.client != nil
+}
+
+func (player *Player) IsConnected() bool {
+	return player.disconnectedAt.IsZero()
+}
+
+// SetConnection binds the seat to the connection, its events come to this player.
+func (player *Player) SetConnection(pConn *PlayerConnection) {
+	assert.NotNil(pConn, "player connection was nil")
+
+	player.connectionID = pConn.uuid
+	player.pConn = pConn
+	player.disconnectedAt = time.Time{}
+	pConn.SetNextHandler(player)
+}
+
+// GetName identifies the player in match records.
+func (player *Player) GetName() string {
+	if player.IsBot() {
+		return "bot"
+	}
+
+	return player.connectionID.String()
+}
+
+// GetDisplayName returns name chosen by the player, GetName identifies the player in records.
+func (player *Player) GetDisplayName() string {
+	if player.IsBot() {
+		return "bot"
+	}
+
+	return player.name
+}
+
 func (player *Player) Handle(e event.Event) {
 	eType := e.GetType()
 
@@ -47,6 +100,62 @@ func (player *Player) Handle(e event.Event) {
 
 		player.handleDisconnect(eDisconnect)
 
+	case event.EventTypeTakeBackRequest:
+		eRequest, ok := e.(EventTakeBackRequest)
+		assert.Assert(ok, "type assertion failed for event take-back request")
+
+		eRequest.Player = player
+		player.sendToNextHandler(eRequest)
+
+	case event.EventTypeTakeBackAnswer:
+		eAnswer, ok := e.(EventTakeBackAnswer)
+		assert.Assert(ok, "type assertion failed for event take-back answer")
+
+		eAnswer.Player = player
+		player.sendToNextHandler(eAnswer)
+
+	case event.EventTypeGameStateRequest:
+		eRequest, ok := e.(EventGameStateRequest)
+		assert.Assert(ok, "type assertion failed for event game state request")
+
+		eRequest.Player = player
+		player.sendToNextHandler(eRequest)
+
+	case event.EventTypeResign:
+		eResign, ok := e.(EventResign)
+		assert.Assert(ok, "type assertion failed for event resign")
+
+		eResign.Player = player
+		player.sendToNextHandler(eResign)
+
+	case event.EventTypeDrawOffer:
+		eOffer, ok := e.(EventDrawOffer)
+		assert.Assert(ok, "type assertion failed for event draw offer")
+
+		eOffer.Player = player
+		player.sendToNextHandler(eOffer)
+
+	case event.EventTypeDrawAnswer:
+		eAnswer, ok := e.(EventDrawAnswer)
+		assert.Assert(ok, "type assertion failed for event draw answer")
+
+		eAnswer.Player = player
+		player.sendToNextHandler(eAnswer)
+
+	case event.EventTypeRematchRequest:
+		eRequest, ok := e.(EventRematchRequest)
+		assert.Assert(ok, "type assertion failed for event rematch request")
+
+		eRequest.Player = player
+		player.sendToNextHandler(eRequest)
+
+	case event.EventTypeRematchAnswer:
+		eAnswer, ok := e.(EventRematchAnswer)
+		assert.Assert(ok, "type assertion failed for event rematch answer")
+
+		eAnswer.Player = player
+		player.sendToNextHandler(eAnswer)
+
 	default:
 		player.sendToNextHandler(e)
 	}
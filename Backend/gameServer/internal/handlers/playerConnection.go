@@ -4,6 +4,7 @@ import (
 	"log/slog"
 
 	"GridPlay/assert"
+	"GridPlay/engine"
 	"GridPlay/gameServer/internal/connection"
 	"GridPlay/gameServer/internal/event"
 	"GridPlay/gameServer/message/clientMsg"
@@ -17,6 +18,11 @@ type PlayerConnection struct {
 	serverHandler Handler
 	uuid uuid.UUID
 	connection *connection.Connection
+	botDifficulty engine.Difficulty
+	// Name shown to other players, e.g. in the lobby.
+	name string
+	// Name was proven with its token, only such players play ranked games.
+	verified bool
 	stopLoop chan bool
 	isLoopRunning bool
 }
@@ -61,26 +67,105 @@ func (playerConn *PlayerConnection) GetConnection() *connection.Connection {
 	return playerConn.connection;
 }
 
+// GetBotDifficulty returns difficulty of a bot this player wants to play against, when there is no opponent.
+func (playerConn *PlayerConnection) GetBotDifficulty() engine.Difficulty {
+	return playerConn.botDifficulty
+}
+
+func (playerConn *PlayerConnection) SetBotDifficulty(difficulty engine.Difficulty) {
+	playerConn.botDifficulty = difficulty
+}
+
+func (playerConn *PlayerConnection) GetUUID() uuid.UUID {
+	return playerConn.uuid
+}
+
+func (playerConn *PlayerConnection) GetName() string {
+	return playerConn.name
+}
+
+func (playerConn *PlayerConnection) SetName(name string) {
+	playerConn.name = name
+}
+
+func (playerConn *PlayerConnection) IsVerified() bool {
+	return playerConn.verified
+}
+
+func (playerConn *PlayerConnection) SetVerified(verified bool) {
+	playerConn.verified = verified
+}
+
 func (playerConn *PlayerConnection) SetNextHandler(nextHandler Handler) {
 	assert.NotNil(nextHandler, "next handler was nil")
 
 	playerConn.nextHandler = nextHandler
 }
 
+// ClearNextHandler detaches the connection from a room, its events go to the server handler again.
+func (playerConn *PlayerConnection) ClearNextHandler() {
+	playerConn.nextHandler = nil
+}
+
+// IsInRoom is true when events of the connection go to a room, as a player or spectator.
+func (pConn *PlayerConnection) IsInRoom() bool {
+	return pConn.nextHandler != nil
+}
+
 func (pConn *PlayerConnection) Handle(e event.Event) {
-	if pConn.nextHandler != nil {
+	lobbyEvent, isLobbyEvent := pConn.toLobbyEvent(e)
+
+	switch {
+	case isLobbyEvent && pConn.nextHandler == nil:
+		pConn.sendToServerHandler(lobbyEvent)
+	case isLobbyEvent:
+		pConn.sendNotAllowed("cannot do this during a game")
+	case pConn.nextHandler != nil:
 		pConn.nextHandler.Handle(e)
-	} else {
-		slog.Info("cannot do this while game is not running")
-
-		message := serverMsg.MakeMessage(serverMsg.TNotAllowedErr, &serverMsg.NotAllowedErrMessage{
-			Reason: "cannot do this while game is not running",
-		})
+	default:
+		pConn.sendNotAllowed("cannot do this while game is not running")
+	}
+}
 
-		pConn.GetConnection().SendMessage(message)
+// toLobbyEvent fills in the connection of events handled by the server, e.g. joining a private room.
+func (pConn *PlayerConnection) toLobbyEvent(e event.Event) (event.Event, bool) {
+	switch lobbyEvent := e.(type) {
+	case EventCreatePrivateRoom:
+		lobbyEvent.ConnectionId = pConn.uuid
+		return lobbyEvent, true
+	case EventJoinPrivateRoom:
+		lobbyEvent.ConnectionId = pConn.uuid
+		return lobbyEvent, true
+	case EventOpenRoom:
+		lobbyEvent.ConnectionId = pConn.uuid
+		return lobbyEvent, true
+	case EventJoinRoom:
+		lobbyEvent.ConnectionId = pConn.uuid
+		return lobbyEvent, true
+	case EventLobbyRequest:
+		lobbyEvent.ConnectionId = pConn.uuid
+		return lobbyEvent, true
+	case EventLobbySubscribe:
+		lobbyEvent.ConnectionId = pConn.uuid
+		return lobbyEvent, true
+	case EventEnqueue:
+		lobbyEvent.ConnectionId = pConn.uuid
+		return lobbyEvent, true
+	default:
+		return e, false
 	}
 }
 
+func (pConn *PlayerConnection) sendNotAllowed(reason string) {
+	slog.Info(reason)
+
+	message := serverMsg.MakeMessage(serverMsg.TNotAllowedErr, &serverMsg.NotAllowedErrMessage{
+		Reason: reason,
+	})
+
+	pConn.GetConnection().SendMessage(message)
+}
+
 func (pConn *PlayerConnection) loop() {
 	conn := pConn.GetConnection()
 	remoteIP := conn.GetRemoteIP()
@@ -2,53 +2,129 @@ package handlers
 
 import (
 	"GridPlay/assert"
-	"GridPlay/game"
+	"GridPlay/engine"
 	"GridPlay/game/winState"
+	"GridPlay/gameRules"
+	"GridPlay/gameServer/message"
+	"GridPlay/gameServer/internal/matchLog"
 	"GridPlay/gameServer/message/serverMsg"
+	"GridPlay/rating"
+	"GridPlay/record"
+	"encoding/json"
 	"errors"
 	"log/slog"
+	"math"
+	"math/rand"
+	"time"
 
 	"GridPlay/gameServer/internal/event"
 
 	"github.com/google/uuid"
 )
 
+// DrawOfferInterval is the shortest time between two draw offers of a player.
+const DrawOfferInterval = 30 * time.Second
+
+// MaxSpectators is the number of spectators a room accepts, others are turned away.
+const MaxSpectators = 64
+
 type Room struct {
 	nextHandler Handler
 	uuid uuid.UUID
 	sync *Synchronizer
-	game        *game.Game
+	rules       gameRules.GameRules
+	config RoomConfig
+	// Token of every seat, a player reconnects with it after the connection dropped.
+	resumeTokens [2]string
+	record *record.Record
+	// Room uuid for the first game, every rematch gets a new one.
+	recordUUID uuid.UUID
+	gamesPlayed int
+	log *matchLog.Log
+	createdAt time.Time
+	// Nil when the game has no time control.
+	clock *Clock
 	players [2]*Player
+	spectators []*Spectator
 	gameActive bool
+	// Player waiting for the opponent to answer take-back request, nil if there is none.
+	takeBackRequester *Player
+	// Player whose draw offer was not answered yet, nil if there is none.
+	drawOfferer *Player
+	drawOfferedAt [2]time.Time
+	// Player waiting for the opponent to answer rematch request, nil if there is none.
+	rematchRequester *Player
+	// Room was removed with players in it, e.g. after a series.
+	closed bool
+	// Rating changes of the players after the last game, nil when it was not ranked.
+	ratingChanges [2]*serverMsg.RatingChange
 }
 
-func CreateRoom(nextHandler Handler, pConnections [2]*PlayerConnection, uuid uuid.UUID) *Room {
-	assert.NotNil(nextHandler, "next handler was nil")
+func CreateRoom(nextHandler Handler, pConnections [2]*PlayerConnection, uuid uuid.UUID, rules gameRules.GameRules, config RoomConfig) *Room {
 	assert.NotNil(pConnections[0], "player connection was nil")
 	assert.NotNil(pConnections[1], "player connection was nil")
 
+	room := createRoom(nextHandler, uuid, rules, config)
+	room.players = room.createPlayers(pConnections)
+	room.startGame()
+
+	assert.Assert(room.gameActive, "gameActive must be true")
+	return room
+}
+
+// CreateBotRoom creates room where the player plays against a bot, seats are random.
+func CreateBotRoom(nextHandler Handler, pConn *PlayerConnection, uuid uuid.UUID, rules gameRules.Searchable, config RoomConfig, createEngine engine.Factory) *Room {
+	assert.NotNil(pConn, "player connection was nil")
+
+	room := createRoom(nextHandler, uuid, rules, config)
+
+	botId := rand.Intn(2)
+	room.players[room.GetOpponentId(botId)] = room.createPlayer(pConn, room.GetOpponentId(botId))
+	room.players[botId] = room.createBotPlayer(botId, rules, createEngine)
+	room.startGame()
+
+	assert.Assert(room.gameActive, "gameActive must be true")
+	return room
+}
+
+func createRoom(nextHandler Handler, roomUUID uuid.UUID, rules gameRules.GameRules, config RoomConfig) *Room {
+	assert.NotNil(nextHandler, "next handler was nil")
+	assert.NotNil(rules, "game rules was nil")
+
 	room := &Room{
 		nextHandler: nextHandler,
-		uuid: uuid,
+		uuid: roomUUID,
+		rules: rules,
+		config: config,
+		resumeTokens: [2]string{uuid.NewString(), uuid.NewString()},
+		recordUUID: roomUUID,
+		log: matchLog.CreateLog(),
+		createdAt: time.Now(),
 		gameActive: false,
 	}
 	room.sync = CreateSynchronizer(room)
-	room.players = room.createPlayers(pConnections)
-	room.game = room.createGame()
 
 	assert.NotNil(room.sync, "room sync was nil")
-	assert.NotNil(room.game, "game was nil")
-	room.startGame()
-
-	assert.Assert(room.gameActive, "gameActive must be true")
 	return room
 }
 
 func (room *Room) startGame() {
 	assert.Assert(!room.gameActive, "game already started")
 
+	room.ratingChanges = [2]*serverMsg.RatingChange{}
+	room.record = record.CreateRecord(room.rules.GetName(), room.config.Params, [2]string{
+		room.players[0].GetName(),
+		room.players[1].GetName(),
+	}, time.Now())
+
+	if room.config.TimeControl.IsLimited() {
+		room.clock = CreateClock(room.config.TimeControl, room.rules.GetCurrentPlayer(), time.Now())
+	}
+
 	room.sendMatchStartedMessage(room.players[0])
 	room.sendMatchStartedMessage(room.players[1])
+	room.sendGameState(room.players[0])
+	room.sendGameState(room.players[1])
 	room.gameActive = true
 }
 
@@ -56,6 +132,60 @@ func (room *Room) GetUUID() uuid.UUID {
 	return room.uuid
 }
 
+func (room *Room) GetLog() *matchLog.Log {
+	return room.log
+}
+
+// RoomInfo describes the room in room listings.
+type RoomInfo struct {
+	UUID uuid.UUID `json:"id"`
+	Game string `json:"game"`
+	Params gameRules.Params `json:"params"`
+	// Players are names of the players, empty for a seat which was left.
+	Players [2]string `json:"players"`
+	Spectators int `json:"spectators"`
+	Bot bool `json:"bot"`
+	BestOf int `json:"bestOf"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// GetInfo must not run concurrently with Update.
+func (room *Room) GetInfo() RoomInfo {
+	assert.NotNil(room.rules, "game rules was nil")
+
+	bot := false
+	var players [2]string
+
+	for i, player := range room.players {
+		bot = bot || (player != nil && player.IsBot())
+
+		if player != nil {
+			players[i] = player.GetDisplayName()
+		}
+	}
+
+	return RoomInfo{
+		UUID: room.uuid,
+		Game: room.rules.GetName(),
+		Params: room.config.Params,
+		Players: players,
+		Spectators: len(room.spectators),
+		Bot: bot,
+		BestOf: max(room.config.BestOf, 1),
+		CreatedAt: room.createdAt,
+	}
+}
+
+// AddSpectator can be called from any goroutine, the spectator joins on the next Update.
+func (room *Room) AddSpectator(pConn *PlayerConnection) {
+	assert.NotNil(room.sync, "room sync was nil")
+	assert.NotNil(pConn, "player connection was nil")
+
+	room.sync.Handle(EventSpectatorJoin{
+		Connection: pConn,
+	})
+}
+
 func (room *Room) createPlayers(pConnections [2]*PlayerConnection) [2]*Player {
 	assert.NotNil(pConnections, "player connection array was nil")
 
@@ -73,41 +203,79 @@ func (room *Room) createPlayer(pConn *PlayerConnection, playerId int) *Player {
 	assert.NotNil(pConn, "player connection was nil")
 
 	player := CreatePlayer(room.sync, pConn.uuid, playerId)
-	pConn.SetNextHandler(player)
+	player.name = pConn.GetName()
+	player.SetConnection(pConn)
 
 	return player
 }
 
-func (room *Room) createGame() *game.Game {
-	game := game.CreateGame()
-	assert.NotNil(game, "game was nil")
+func (room *Room) createBotPlayer(playerId int, rules gameRules.Searchable, createEngine engine.Factory) *Player {
+	assert.NotNil(room.sync, "room sync was nil")
+
+	player := CreatePlayer(room.sync, uuid.New(), playerId)
+	player.SetClient(CreateBot(player, rules, createEngine))
 
-	return game
+	return player
 }
 
 func (room *Room) sendMatchStartedMessage(player *Player) {
 	assert.NotNil(player, "player was nil")
-	assert.NotNil(room.game, "game was nil")
+	assert.NotNil(room.rules, "game rules was nil")
 
-	gamePlayer := room.game.GetPlayerWithId(player.playerID)
-	playerChar := gamePlayer.GetChar()
-	opponentChar := game.OpponentChar(playerChar)
+	opponentId := room.GetOpponentId(player.playerID)
 
 	matchStartMsg := serverMsg.MakeMessage(serverMsg.TMatchStarted, &serverMsg.MatchStarted{
-		Char: playerChar.GetRune(),
-		OpponentChar: opponentChar.GetRune(),
+		Game: room.rules.GetName(),
+		Char: room.rules.GetPlayerSymbol(player.playerID),
+		OpponentChar: room.rules.GetPlayerSymbol(opponentId),
+		ResumeToken: room.resumeTokens[player.playerID],
+		Series: room.getSeries(player),
 	})
 
-	room.sendToNextHandler(EventSendMessage{
-		ConnectionId: player.connectionID,
-		Msg: matchStartMsg,
-	})
+	room.sendMessage(player, matchStartMsg)
 }
 
 func (room *Room) Update() {
 	assert.NotNil(room.sync, "room sync was nil")
 
 	room.sync.SyncTransferAll(); 
+	room.checkReconnectGrace()
+	room.checkClock()
+}
+
+// checkClock ends the game when the player on the move runs out of time.
+func (room *Room) checkClock() {
+	if room.clock == nil || !room.gameActive || room.gameHasEnded() {
+		return
+	}
+
+	if !room.clock.IsFlagged(time.Now()) {
+		return
+	}
+
+	loserId := room.clock.GetRunning()
+	winnerId := room.GetOpponentId(loserId)
+
+	slog.Info("player ran out of time", "room", room.uuid, "seat", loserId)
+
+	room.endGame(record.ResultOfWinner(winnerId), record.TerminationTimeout, serverMsg.CauseTimeout)
+}
+
+// getClockMessage returns nil when the game has no time control.
+func (room *Room) getClockMessage() *serverMsg.Clock {
+	if room.clock == nil {
+		return nil
+	}
+
+	now := time.Now()
+
+	return &serverMsg.Clock{
+		Remaining: [2]int64{
+			room.clock.GetRemaining(0, now).Milliseconds(),
+			room.clock.GetRemaining(1, now).Milliseconds(),
+		},
+		Running: room.clock.GetRunning(),
+	}
 }
 
 func (room *Room) Handle(e event.Event) { 
@@ -115,6 +283,13 @@ func (room *Room) Handle(e event.Event) {
 
 	slog.Debug("event in room", "Type", eType, "event", e)
 
+	if room.closed {
+		room.handleClosed(e)
+		return
+	}
+
+	room.logInbound(e)
+
 	switch eType {
 	case event.EventTypeMove:
 		eMove, ok := e.(EventMove)
@@ -128,18 +303,134 @@ func (room *Room) Handle(e event.Event) {
 
 		room.handleDisconnect(eDisconnect)
 
+	case event.EventTypeTakeBackRequest:
+		eRequest, ok := e.(EventTakeBackRequest)
+		assert.Assert(ok, "type assertion failed for event take-back request")
+
+		room.handleTakeBackRequest(eRequest)
+
+	case event.EventTypeTakeBackAnswer:
+		eAnswer, ok := e.(EventTakeBackAnswer)
+		assert.Assert(ok, "type assertion failed for event take-back answer")
+
+		room.handleTakeBackAnswer(eAnswer)
+
+	case event.EventTypeSpectatorJoin:
+		eJoin, ok := e.(EventSpectatorJoin)
+		assert.Assert(ok, "type assertion failed for event spectator join")
+
+		room.handleSpectatorJoin(eJoin)
+
+	case event.EventTypeSpectator:
+		eSpectator, ok := e.(EventSpectator)
+		assert.Assert(ok, "type assertion failed for event spectator")
+
+		room.handleSpectatorEvent(eSpectator)
+
+	case event.EventTypeResume:
+		eResume, ok := e.(EventResume)
+		assert.Assert(ok, "type assertion failed for event resume")
+
+		room.handleResume(eResume)
+
+	case event.EventTypeGameStateRequest:
+		eRequest, ok := e.(EventGameStateRequest)
+		assert.Assert(ok, "type assertion failed for event game state request")
+		assert.NotNil(eRequest.Player, "event game state request player was nil")
+
+		room.sendGameState(eRequest.Player)
+
+	case event.EventTypeResign:
+		eResign, ok := e.(EventResign)
+		assert.Assert(ok, "type assertion failed for event resign")
+
+		room.handleResign(eResign)
+
+	case event.EventTypeDrawOffer:
+		eOffer, ok := e.(EventDrawOffer)
+		assert.Assert(ok, "type assertion failed for event draw offer")
+
+		room.handleDrawOffer(eOffer)
+
+	case event.EventTypeDrawAnswer:
+		eAnswer, ok := e.(EventDrawAnswer)
+		assert.Assert(ok, "type assertion failed for event draw answer")
+
+		room.handleDrawAnswer(eAnswer)
+
+	case event.EventTypeRematchRequest:
+		eRequest, ok := e.(EventRematchRequest)
+		assert.Assert(ok, "type assertion failed for event rematch request")
+
+		room.handleRematchRequest(eRequest)
+
+	case event.EventTypeRematchAnswer:
+		eAnswer, ok := e.(EventRematchAnswer)
+		assert.Assert(ok, "type assertion failed for event rematch answer")
+
+		room.handleRematchAnswer(eAnswer)
+
 	default:
 		room.sendToNextHandler(e)
 	}
 }
 
+// logInbound writes event from a player to the match log.
+func (room *Room) logInbound(e event.Event) {
+	assert.NotNil(room.log, "room log was nil")
+
+	seat := matchLog.NoSeat
+	var data any
+
+	switch e := e.(type) {
+	case EventMove:
+		seat = e.Player.playerID
+		// Valid json, it was decoded from client message or marshaled by a bot.
+		data = json.RawMessage(e.Data)
+	case EventDisconnect:
+		seat = e.Player.playerID
+	case EventTakeBackRequest:
+		seat = e.Player.playerID
+	case EventTakeBackAnswer:
+		seat = e.Player.playerID
+		data = e.Accept
+	case EventGameStateRequest:
+		seat = e.Player.playerID
+	case EventResign:
+		seat = e.Player.playerID
+	case EventDrawOffer:
+		seat = e.Player.playerID
+	case EventDrawAnswer:
+		seat = e.Player.playerID
+		data = e.Accept
+	case EventRematchRequest:
+		seat = e.Player.playerID
+	case EventRematchAnswer:
+		seat = e.Player.playerID
+		data = e.Accept
+	case EventSpectator:
+		data = e.Event.GetType().String()
+	}
+
+	room.log.Add(matchLog.Inbound, seat, e.GetType().String(), data)
+}
+
 func (room *Room) handleDisconnect(eDisconnect EventDisconnect) {
 	assert.NotNil(eDisconnect.Player, "event disconnect player was nil")
-	assert.NotNil(room.game, "game was nil")
+	assert.NotNil(room.rules, "game rules was nil")
 
 	room.sendToNextHandler(eDisconnect)
 
-	playerId := eDisconnect.Player.playerID
+	if room.canHoldSeat() {
+		room.holdSeat(eDisconnect.Player)
+		return
+	}
+
+	room.leaveSeat(eDisconnect.Player.playerID)
+}
+
+// leaveSeat removes the player from the room, opponent wins if the game was still running.
+func (room *Room) leaveSeat(playerId int) {
 	opponentId := room.GetOpponentId(playerId)
 
 	if room.gameActive {
@@ -150,6 +441,97 @@ func (room *Room) handleDisconnect(eDisconnect EventDisconnect) {
 	}
 }
 
+func (room *Room) canHoldSeat() bool {
+	return room.gameActive && !room.gameHasEnded() && room.config.ReconnectGrace > 0
+}
+
+// holdSeat keeps the seat of disconnected player, so it can resume the game.
+func (room *Room) holdSeat(player *Player) {
+	assert.NotNil(player, "player was nil")
+	assert.Assert(!player.IsBot(), "bot cannot disconnect")
+
+	player.disconnectedAt = time.Now()
+	slog.Info("holding seat of disconnected player", "room", room.uuid, "seat", player.playerID)
+
+	opponent := room.GetOpponent(player.playerID)
+	room.sendMessage(opponent, serverMsg.MakeMessage(serverMsg.TOpponentConnection, serverMsg.OpponentConnection{
+		Connected: false,
+		Grace: int(room.config.ReconnectGrace.Seconds()),
+	}))
+}
+
+// checkReconnectGrace removes players who did not reconnect in time.
+func (room *Room) checkReconnectGrace() {
+	for i, player := range room.players {
+		if player == nil || player.IsConnected() {
+			continue
+		}
+
+		if time.Since(player.disconnectedAt) >= room.config.ReconnectGrace {
+			slog.Info("player did not reconnect", "room", room.uuid, "seat", i)
+			room.leaveSeat(i)
+		}
+	}
+}
+
+// HasResumeToken can be called from any goroutine.
+func (room *Room) HasResumeToken(token string) bool {
+	return room.getResumeSeat(token) >= 0
+}
+
+// getResumeSeat returns -1 if the token does not belong to this room.
+func (room *Room) getResumeSeat(token string) int {
+	for seat, t := range room.resumeTokens {
+		if t == token {
+			return seat
+		}
+	}
+
+	return -1
+}
+
+// Resume can be called from any goroutine, the connection takes the seat on the next Update.
+func (room *Room) Resume(pConn *PlayerConnection, token string) {
+	assert.NotNil(room.sync, "room sync was nil")
+	assert.NotNil(pConn, "player connection was nil")
+
+	room.sync.Handle(EventResume{
+		Connection: pConn,
+		Token: token,
+	})
+}
+
+func (room *Room) handleResume(eResume EventResume) {
+	assert.NotNil(eResume.Connection, "event resume connection was nil")
+
+	seat := room.getResumeSeat(eResume.Token)
+
+	if seat < 0 || room.players[seat] == nil || room.players[seat].IsConnected() {
+		room.sendToNextHandler(EventSendMessage{
+			ConnectionId: eResume.Connection.uuid,
+			Msg: serverMsg.MakeMessage(serverMsg.TNotAllowedErr, &serverMsg.NotAllowedErrMessage{
+				Reason: "this seat cannot be resumed",
+			}),
+		})
+		return
+	}
+
+	player := room.players[seat]
+	player.SetConnection(eResume.Connection)
+
+	slog.Info("player reconnected", "room", room.uuid, "seat", seat)
+
+	room.sendMatchStartedMessage(player)
+	room.sendGameState(player)
+
+	opponent := room.players[room.GetOpponentId(seat)]
+	if opponent != nil {
+		room.sendMessage(opponent, serverMsg.MakeMessage(serverMsg.TOpponentConnection, serverMsg.OpponentConnection{
+			Connected: true,
+		}))
+	}
+}
+
 func (room *Room) handleDisconnectFirstPlayer(playerId, opponentId int) {
 	assert.NotNil(room.players, "players was nil")
 	assert.Assert(room.gameActive, "game should be active")
@@ -160,11 +542,25 @@ func (room *Room) handleDisconnectFirstPlayer(playerId, opponentId int) {
 	assert.NotNil(opponent, "opponent should not be nil")
 
 	if !room.gameHasEnded() {
-		room.gameEndWinOnePlayerHandler(opponent.connectionID)
+		room.finishRecord(record.ResultOfWinner(opponentId), record.TerminationDisconnect)
+		room.gameEndWinOnePlayerHandler(opponent, serverMsg.CauseDisconnect)
+
+		// Player who left forfeits the rest of the series, there is nothing to play in the room anymore.
+		if room.isSeries() {
+			room.sendSeriesEnd(opponent)
+			room.closeRoom()
+			return
+		}
 	}
 
 	room.players[playerId] = nil
 	room.gameActive = false
+	room.rematchRequester = nil
+
+	// Bot never disconnects by itself.
+	if opponent.IsBot() {
+		room.handleDisconnectLastPlayer(opponentId, playerId)
+	}
 }
 
 func (room *Room) handleDisconnectLastPlayer(playerId, opponentId int) {
@@ -176,6 +572,12 @@ func (room *Room) handleDisconnectLastPlayer(playerId, opponentId int) {
 	// This player disconnect last, so opponent should NOT be online and shouldn't be in room.
 	assert.Assert(opponent == nil, "opponent should be nil")
 
+	// Spectators stay connected, but they are not in the room anymore.
+	for _, spectator := range room.spectators {
+		spectator.pConn.ClearNextHandler()
+	}
+	room.spectators = nil
+
 	eRemoveRoom := EventRemoveRoom{
 		RoomUUID: room.GetUUID(),
 	}
@@ -187,7 +589,13 @@ func (room *Room) handleDisconnectLastPlayer(playerId, opponentId int) {
 func (room *Room) handleMove(eMove EventMove) {
 	assert.NotNil(eMove.Player, "event move player was nil")
 
-	err := room.eMovePlayer(eMove)
+	// Bot searched this move before the next game started, it is not waiting for an answer anymore.
+	if eMove.Player.IsBot() && eMove.Game != room.gamesPlayed {
+		slog.Info("dropped bot move of previous game", "room", room.uuid, "game", eMove.Game)
+		return
+	}
+
+	move, result, err := room.eMovePlayer(eMove)
 
 	if err != nil {
 		room.eMoveSendErrorResponse(err, eMove.Player)
@@ -196,29 +604,51 @@ func (room *Room) handleMove(eMove EventMove) {
 
 	assert.Assert(room.gameActive, "game should be active")
 
-	room.eMoveSendSuccessResponse(eMove.Player)
+	// Decoded move is recorded, so unknown fields the client sent are not kept.
+	data, err := json.Marshal(move)
+	assert.NoError(err, "decoded move must be serializable")
+
+	err = room.record.AddMove(data)
+	assert.NoError(err, "applied move must be valid json")
+
+	if room.clock != nil {
+		room.clock.Press(room.rules.GetCurrentPlayer(), time.Now())
+	}
+
+	room.cancelTakeBack("take-back was canceled by a move")
+	room.cancelDrawOffer("draw offer was declined by a move")
+	room.eMoveSendSuccessResponse(eMove.Player, result)
 
 	opponent := room.GetOpponent(eMove.Player.playerID)
-	room.eMoveSendMessageToOpponent(eMove, opponent)
+	room.eMoveSendMessageToOpponent(result, opponent)
+
+	room.sendToSpectators(serverMsg.MakeMessage(serverMsg.TSpectatorMove, serverMsg.SpectatorMove{
+		Seat: eMove.Player.playerID,
+		Move: result,
+	}))
 
-	room.checkGameWin(eMove)
+	room.checkGameWin()
 }
 
-func (room *Room) eMovePlayer(eMove EventMove) error {
-	assert.NotNil(room.game, "game was nil")
+func (room *Room) eMovePlayer(eMove EventMove) (gameRules.Move, gameRules.MoveResult, error) {
+	assert.NotNil(room.rules, "game rules was nil")
 	assert.NotNil(eMove.Player, "event move player was nil")
 
-	var err error
-	currPlayer := room.game.GetCurrentRoundPlayer()
-	gamePlayer := room.game.GetPlayerWithId(eMove.Player.playerID)
+	if room.gameHasEnded() {
+		return nil, nil, errors.New("cannot move after game ended")
+	}
 
-	if currPlayer == gamePlayer {
-		err = room.game.Move(game.Pos{X: eMove.X, Y: eMove.Y})
-	} else {
-		err = errors.New("not your round, dummy")
+	if room.rules.GetCurrentPlayer() != eMove.Player.playerID {
+		return nil, nil, errors.New("not your round, dummy")
+	}
+
+	move, err := room.rules.DecodeMove(eMove.Data)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return err
+	result, err := room.rules.ApplyMove(eMove.Player.playerID, move)
+	return move, result, err
 }
 
 func (room *Room) eMoveSendErrorResponse(err error, player *Player) {
@@ -230,56 +660,627 @@ func (room *Room) eMoveSendErrorResponse(err error, player *Player) {
 	msg := serverMsg.MakeMessage(serverMsg.TMoveAns, serverMsg.MoveRes{
 		Approved: false,
 		Reason: err.Error(),
+		Clock: room.getClockMessage(),
 	})
 
-	room.sendToNextHandler(EventSendMessage{
-		ConnectionId: player.connectionID,
-		Msg: msg,
-	})
+	room.sendMessage(player, msg)
 }
 
-func (room *Room) eMoveSendSuccessResponse(player *Player) {
+func (room *Room) eMoveSendSuccessResponse(player *Player, result gameRules.MoveResult) {
 	assert.NotNil(player, "player was nil")
 
 	msg := serverMsg.MakeMessage(serverMsg.TMoveAns, serverMsg.MoveRes{
 		Approved: true,
+		Move: result,
+		Clock: room.getClockMessage(),
 	})
+
+
+	room.sendMessage(player, msg)
+}
+
+func (room *Room) eMoveSendMessageToOpponent(result gameRules.MoveResult, opponent *Player) {
+	assert.NotNil(opponent, "opponent was nil")
+
+	msgForOpponent := serverMsg.MakeMessage(serverMsg.TOpponentMove, serverMsg.MoveMessage(result))
+
+	room.sendMessage(opponent, msgForOpponent)
+}
+
+func (room *Room) checkGameWin() {
+	assert.NotNil(room.rules, "game rules was nil")
+	assert.Assert(room.gameActive, "game should be active")
+
+	wState := room.rules.GetOutcome()
 	
+	if wState != winState.Values.None {
+		room.endGame(record.ResultFromOutcome(wState), record.TerminationNormal, "")
+	}
+}
 
-	room.sendToNextHandler(EventSendMessage{
-		ConnectionId: player.connectionID,
-		Msg: msg,
+// endGame finishes the game of both players in the room, on the board or off it, e.g. by resignation.
+// In a series the next game starts, unless one of the players has clinched it.
+func (room *Room) endGame(result record.Result, termination record.Termination, cause string) {
+	assert.Assert(room.gameActive, "game should be active")
+	assert.Assert(result != record.ResultUnfinished, "game must have a result")
+
+	room.cancelTakeBack("game has ended")
+	room.cancelDrawOffer("game has ended")
+	room.finishRecord(result, termination)
+
+	switch result {
+	case record.ResultFirstWin:
+		room.gameEndWinHandler(room.players[0], room.players[1], cause)
+	case record.ResultSecondWin:
+		room.gameEndWinHandler(room.players[1], room.players[0], cause)
+	default:
+		room.gameEndDrawHandler(room.players[0], room.players[1], cause)
+	}
+
+	if !room.isSeries() {
+		return
+	}
+
+	if !room.isSeriesDecided() {
+		room.startNextGame()
+		return
+	}
+
+	room.sendSeriesEnd(room.players[0])
+	room.sendSeriesEnd(room.players[1])
+	room.closeRoom()
+}
+
+// finishRecord hands the finished match record over to the server.
+func (room *Room) finishRecord(result record.Result, termination record.Termination) {
+	assert.NotNil(room.record, "room record was nil")
+
+	room.record.Finish(result, termination)
+	room.addToSeries(result)
+	room.updateRatings(result)
+
+	room.sendToSpectators(serverMsg.MakeMessage(serverMsg.TGameEnd, serverMsg.GameEnd{
+		Result: string(result),
+		Termination: string(termination),
+	}))
+
+	room.sendToNextHandler(EventGameRecord{
+		RoomUUID: room.uuid,
+		RecordUUID: room.recordUUID,
+		Record: room.record,
 	})
 }
 
-func (room *Room) eMoveSendMessageToOpponent(eMove EventMove, opponent *Player) {
-	assert.NotNil(opponent, "opponent was nil")
+// updateRatings rates the game, if the room is ranked.
+func (room *Room) updateRatings(result record.Result) {
+	if room.config.Ratings == nil {
+		return
+	}
+
+	var score float64
+
+	switch result {
+	case record.ResultFirstWin:
+		score = 1
+	case record.ResultSecondWin:
+		score = 0
+	case record.ResultDraw:
+		score = 0.5
+	default:
+		assert.Never("unfinished game cannot be rated", "result", result)
+	}
+
+	names := [2]string{room.players[0].GetDisplayName(), room.players[1].GetDisplayName()}
+	changes := room.config.Ratings.Update(room.rules.GetName(), names, score, time.Now())
+
+	for i, change := range changes {
+		room.ratingChanges[i] = createRatingChange(change)
+	}
+
+	slog.Info("rated game", "room", room.uuid, "players", names, "result", result)
+}
+
+func createRatingChange(change rating.Change) *serverMsg.RatingChange {
+	return &serverMsg.RatingChange{
+		Before: math.Round(change.Before.Value),
+		After: math.Round(change.After.Value),
+		Deviation: math.Round(change.After.Deviation),
+	}
+}
+
+func (room *Room) addToSeries(result record.Result) {
+	room.gamesPlayed++
+
+	switch result {
+	case record.ResultFirstWin:
+		room.players[0].score += 1
+	case record.ResultSecondWin:
+		room.players[1].score += 1
+	case record.ResultDraw:
+		room.players[0].score += 0.5
+		room.players[1].score += 0.5
+	}
+}
+
+// getSeries returns the series score as seen by the player.
+func (room *Room) getSeries(player *Player) serverMsg.Series {
+	assert.NotNil(player, "player was nil")
+
+	series := serverMsg.Series{
+		BestOf: max(room.config.BestOf, 1),
+		Games: room.gamesPlayed,
+		Score: player.score,
+	}
+
+	if opponent := room.players[room.GetOpponentId(player.playerID)]; opponent != nil {
+		series.OpponentScore = opponent.score
+	}
+
+	return series
+}
+
+func (room *Room) handleTakeBackRequest(eRequest EventTakeBackRequest) {
+	assert.NotNil(eRequest.Player, "event take-back request player was nil")
+
+	moves, err := room.checkTakeBack(eRequest.Player)
+
+	if err != nil {
+		room.sendTakeBackAnswer(eRequest.Player, false, err.Error())
+		return
+	}
+
+	room.takeBackRequester = eRequest.Player
+
+	opponent := room.GetOpponent(eRequest.Player.playerID)
+	msg := serverMsg.MakeMessage(serverMsg.TTakeBackRequest, serverMsg.TakeBackRequest{
+		Moves: moves,
+	})
+
+	room.sendMessage(opponent, msg)
+}
+
+// checkTakeBack returns number of moves to take back so the requester is on the move again.
+func (room *Room) checkTakeBack(requester *Player) (int, error) {
+	assert.NotNil(room.rules, "game rules was nil")
+	assert.NotNil(requester, "requester was nil")
+
+	if !room.gameActive || room.gameHasEnded() {
+		return 0, errors.New("cannot take back after game ended")
+	}
+
+	undoable, ok := room.rules.(gameRules.Undoable)
+	if !ok {
+		return 0, errors.New("take-back is not supported in this game")
+	}
+
+	if room.takeBackRequester != nil {
+		return 0, errors.New("take-back was already requested")
+	}
+
+	// Opponent has already answered the requester's move, take back both.
+	moves := 1
+	if room.rules.GetCurrentPlayer() == requester.playerID {
+		moves = 2
+	}
+
+	if undoable.GetMoveCount() < moves {
+		return 0, errors.New("there is no move to take back")
+	}
+
+	return moves, nil
+}
+
+func (room *Room) handleTakeBackAnswer(eAnswer EventTakeBackAnswer) {
+	assert.NotNil(eAnswer.Player, "event take-back answer player was nil")
+
+	requester := room.takeBackRequester
+
+	if requester == nil || requester == eAnswer.Player {
+		room.sendNotAllowed(eAnswer.Player, "there is no take-back request to answer")
+		return
+	}
+
+	if !eAnswer.Accept {
+		room.cancelTakeBack("opponent declined take-back")
+		return
+	}
+
+	room.takeBackRequester = nil
+	moves, err := room.checkTakeBack(requester)
+
+	if err != nil {
+		room.sendTakeBackAnswer(requester, false, err.Error())
+		return
+	}
+
+	undoable := room.rules.(gameRules.Undoable)
+
+	for range moves {
+		err := undoable.UndoMove()
+		assert.NoError(err, "cannot undo checked take-back")
+	}
+
+	room.record.RemoveMoves(moves)
+
+	if room.clock != nil {
+		room.clock.Switch(room.rules.GetCurrentPlayer(), time.Now())
+	}
 
-	msgForOpponent := serverMsg.MakeMessage(serverMsg.TOpponentMove, &serverMsg.MoveMessage{
-		X: eMove.X,
-		Y: eMove.Y,
+	slog.Debug("take-back", "room", room.uuid, "moves", moves)
+
+	room.sendTakeBackAnswer(requester, true, "")
+	room.sendGameState(room.players[0])
+	room.sendGameState(room.players[1])
+	room.sendToSpectators(room.makeGameStateMessage(matchLog.NoSeat))
+}
+
+// cancelTakeBack rejects pending take-back request, if there is one.
+func (room *Room) cancelTakeBack(reason string) {
+	requester := room.takeBackRequester
+
+	if requester == nil {
+		return
+	}
+
+	room.takeBackRequester = nil
+	room.sendTakeBackAnswer(requester, false, reason)
+}
+
+func (room *Room) sendTakeBackAnswer(player *Player, accepted bool, reason string) {
+	assert.NotNil(player, "player was nil")
+
+	msg := serverMsg.MakeMessage(serverMsg.TTakeBackAns, serverMsg.TakeBackAns{
+		Accepted: accepted,
+		Reason: reason,
 	})
 
-	room.sendToNextHandler(EventSendMessage{
-		ConnectionId: opponent.connectionID,
-		Msg: msgForOpponent,
+	room.sendMessage(player, msg)
+}
+
+// sendGameState sends the complete game, the player redraws everything from it.
+func (room *Room) sendGameState(player *Player) {
+	assert.NotNil(player, "player was nil")
+
+	room.sendMessage(player, room.makeGameStateMessage(player.playerID))
+}
+
+// makeGameStateMessage creates the game state as seen from the seat, NoSeat for spectators.
+func (room *Room) makeGameStateMessage(seat int) message.Message {
+	assert.NotNil(room.rules, "game rules was nil")
+
+	state := gameRules.GetGameState(room.rules)
+
+	// Game can end off the board, e.g. by resignation, rules do not know about it.
+	if room.record != nil && room.record.Result != record.ResultUnfinished {
+		state.Outcome = outcomeOfResult(room.record.Result)
+	}
+
+	return serverMsg.MakeMessage(serverMsg.TGameState, serverMsg.GameState{
+		GameState: state,
+		Seat: seat,
+		Clock: room.getClockMessage(),
 	})
 }
 
-func (room *Room) checkGameWin(eMove EventMove) {
-	assert.NotNil(room.game, "game was nil")
-	assert.NotNil(eMove.Player, "event move player was nil")
-	assert.Assert(room.gameActive, "game should be active")
+func outcomeOfResult(result record.Result) gameRules.Outcome {
+	switch result {
+	case record.ResultFirstWin:
+		return gameRules.Outcome{Status: "win", Winner: 0}
+	case record.ResultSecondWin:
+		return gameRules.Outcome{Status: "win", Winner: 1}
+	case record.ResultDraw:
+		return gameRules.Outcome{Status: "draw", Winner: -1}
+	default:
+		return gameRules.Outcome{Status: "none", Winner: -1}
+	}
+}
 
-	wState := room.game.GetWinState()
-	player := eMove.Player
-	opponent := room.GetOpponent(player.playerID)
-	
-	if wState == winState.Values.Win {
-		room.gameEndWinHandler(player.connectionID, opponent.connectionID)
-	} else if wState == winState.Values.Draw {
-		room.gameEndDrawHandler(player.connectionID, opponent.connectionID)
+func (room *Room) handleResign(eResign EventResign) {
+	assert.NotNil(eResign.Player, "event resign player was nil")
+
+	if !room.gameActive || room.gameHasEnded() {
+		room.sendNotAllowed(eResign.Player, "cannot resign after game ended")
+		return
 	}
+
+	loser := eResign.Player
+	winnerId := room.GetOpponentId(loser.playerID)
+
+	slog.Info("player resigned", "room", room.uuid, "seat", loser.playerID)
+
+	room.endGame(record.ResultOfWinner(winnerId), record.TerminationResignation, serverMsg.CauseResignation)
+}
+
+func (room *Room) handleDrawOffer(eOffer EventDrawOffer) {
+	assert.NotNil(eOffer.Player, "event draw offer player was nil")
+
+	err := room.checkDrawOffer(eOffer.Player)
+
+	if err != nil {
+		room.sendDrawDeclined(eOffer.Player, err.Error())
+		return
+	}
+
+	room.drawOfferer = eOffer.Player
+	room.drawOfferedAt[eOffer.Player.playerID] = time.Now()
+
+	opponent := room.GetOpponent(eOffer.Player.playerID)
+	room.sendMessage(opponent, serverMsg.MakeMessage(serverMsg.TDrawOffer, serverMsg.DrawOffer{}))
+}
+
+// checkDrawOffer allows one offer at a time and limits how often a player offers.
+func (room *Room) checkDrawOffer(offerer *Player) error {
+	assert.NotNil(offerer, "offerer was nil")
+
+	if !room.gameActive || room.gameHasEnded() {
+		return errors.New("cannot offer draw after game ended")
+	}
+
+	if room.drawOfferer != nil {
+		return errors.New("draw was already offered")
+	}
+
+	lastOffer := room.drawOfferedAt[offerer.playerID]
+	if !lastOffer.IsZero() && time.Since(lastOffer) < DrawOfferInterval {
+		return errors.New("draw was offered too recently")
+	}
+
+	return nil
+}
+
+func (room *Room) handleDrawAnswer(eAnswer EventDrawAnswer) {
+	assert.NotNil(eAnswer.Player, "event draw answer player was nil")
+
+	offerer := room.drawOfferer
+
+	if offerer == nil || offerer == eAnswer.Player {
+		room.sendNotAllowed(eAnswer.Player, "there is no draw offer to answer")
+		return
+	}
+
+	if !eAnswer.Accept {
+		room.cancelDrawOffer("opponent declined draw")
+		return
+	}
+
+	room.drawOfferer = nil
+
+	// Game could end by disconnect while the offer was pending.
+	if !room.gameActive || room.gameHasEnded() {
+		room.sendNotAllowed(eAnswer.Player, "cannot accept draw after game ended")
+		return
+	}
+
+	slog.Info("draw agreed", "room", room.uuid)
+
+	room.endGame(record.ResultDraw, record.TerminationAgreement, serverMsg.CauseAgreement)
+}
+
+// cancelDrawOffer declines pending draw offer, if there is one.
+func (room *Room) cancelDrawOffer(reason string) {
+	offerer := room.drawOfferer
+
+	if offerer == nil {
+		return
+	}
+
+	room.drawOfferer = nil
+	room.sendDrawDeclined(offerer, reason)
+}
+
+func (room *Room) sendDrawDeclined(player *Player, reason string) {
+	assert.NotNil(player, "player was nil")
+
+	msg := serverMsg.MakeMessage(serverMsg.TDrawDeclined, serverMsg.DrawDeclined{
+		Reason: reason,
+	})
+
+	room.sendMessage(player, msg)
+}
+
+func (room *Room) sendNotAllowed(player *Player, reason string) {
+	assert.NotNil(player, "player was nil")
+
+	msg := serverMsg.MakeMessage(serverMsg.TNotAllowedErr, &serverMsg.NotAllowedErrMessage{
+		Reason: reason,
+	})
+
+	room.sendMessage(player, msg)
+}
+
+func (room *Room) handleRematchRequest(eRequest EventRematchRequest) {
+	assert.NotNil(eRequest.Player, "event rematch request player was nil")
+
+	err := room.checkRematch()
+
+	if err == nil && room.rematchRequester != nil {
+		err = errors.New("rematch was already requested")
+	}
+
+	if err != nil {
+		room.sendRematchDeclined(eRequest.Player, err.Error())
+		return
+	}
+
+	room.rematchRequester = eRequest.Player
+
+	opponent := room.GetOpponent(eRequest.Player.playerID)
+	room.sendMessage(opponent, serverMsg.MakeMessage(serverMsg.TRematchRequest, serverMsg.RematchRequest{}))
+}
+
+// checkRematch allows rematch after the game ended, while both players are in the room.
+func (room *Room) checkRematch() error {
+	if room.config.CreateRules == nil {
+		return errors.New("rematch is not supported in this room")
+	}
+
+	if room.isSeries() {
+		return errors.New("room plays a series, there is no rematch")
+	}
+
+	// Room is inactive after a player left.
+	if !room.gameActive {
+		return errors.New("opponent has left")
+	}
+
+	if !room.gameHasEnded() {
+		return errors.New("game has not ended yet")
+	}
+
+	return nil
+}
+
+func (room *Room) handleRematchAnswer(eAnswer EventRematchAnswer) {
+	assert.NotNil(eAnswer.Player, "event rematch answer player was nil")
+
+	requester := room.rematchRequester
+
+	if requester == nil || requester == eAnswer.Player {
+		room.sendNotAllowed(eAnswer.Player, "there is no rematch request to answer")
+		return
+	}
+
+	room.rematchRequester = nil
+
+	if !eAnswer.Accept {
+		room.sendRematchDeclined(requester, "opponent declined rematch")
+		return
+	}
+
+	err := room.checkRematch()
+	if err != nil {
+		room.sendRematchDeclined(requester, err.Error())
+		return
+	}
+
+	room.startNextGame()
+}
+
+// startNextGame resets the game in this room, for rematch or next game of a series.
+// Players swap seats, so the other one moves first and they exchange symbols.
+func (room *Room) startNextGame() {
+	assert.NotNil(room.config.CreateRules, "create rules was nil")
+
+	rules, err := room.config.CreateRules()
+	assert.NoError(err, "cannot create rules of the same game again")
+
+	// Symbol of the player on seat 0, it moves to seat 1.
+	symbol := room.rules.GetPlayerSymbol(0)
+
+	room.players[0], room.players[1] = room.players[1], room.players[0]
+	room.players[0].playerID = 0
+	room.players[1].playerID = 1
+	room.resumeTokens[0], room.resumeTokens[1] = room.resumeTokens[1], room.resumeTokens[0]
+
+	// Some games pick symbols at random, the player must not keep its symbol on the other seat.
+	if swapper, ok := rules.(gameRules.SymbolSwapper); ok && rules.GetPlayerSymbol(1) == symbol {
+		swapper.SwapSymbols()
+	}
+
+	for _, player := range room.players {
+		if player.IsBot() {
+			bot, ok := player.client.(*Bot)
+			assert.Assert(ok, "type assertion failed for bot")
+
+			searchable, ok := rules.(gameRules.Searchable)
+			assert.Assert(ok, "bot room rules must be searchable")
+
+			bot.reset(searchable, room.gamesPlayed)
+		}
+	}
+
+	room.rules = rules
+	room.recordUUID = uuid.New()
+	room.clock = nil
+	room.takeBackRequester = nil
+	room.drawOfferer = nil
+	room.drawOfferedAt = [2]time.Time{}
+
+	slog.Info("next game started", "room", room.uuid, "games played", room.gamesPlayed)
+
+	room.gameActive = false
+	room.startGame()
+	room.sendToSpectators(room.makeGameStateMessage(matchLog.NoSeat))
+}
+
+func (room *Room) isSeries() bool {
+	return room.config.BestOf > 1
+}
+
+// isSeriesDecided is true when the leader cannot be caught in the remaining games, or all were played.
+func (room *Room) isSeriesDecided() bool {
+	assert.Assert(room.isSeries(), "room does not play a series")
+
+	remaining := float64(room.config.BestOf - room.gamesPlayed)
+	lead := math.Abs(room.players[0].score - room.players[1].score)
+
+	return remaining <= 0 || lead > remaining
+}
+
+func (room *Room) sendSeriesEnd(player *Player) {
+	assert.NotNil(player, "player was nil")
+
+	series := room.getSeries(player)
+	status := "draw"
+
+	if series.Score > series.OpponentScore {
+		status = "win"
+	} else if series.Score < series.OpponentScore {
+		status = "lose"
+	}
+
+	slog.Debug("series end", "room", room.uuid, "seat", player.playerID, "status", status)
+
+	room.sendMessage(player, serverMsg.MakeMessage(serverMsg.TSeriesEnd, serverMsg.SeriesEnd{
+		Status: status,
+		Series: series,
+	}))
+}
+
+// closeRoom removes the room with players still in it, their connections stay open.
+func (room *Room) closeRoom() {
+	for i, player := range room.players {
+		if player != nil && player.pConn != nil && player.IsConnected() {
+			player.pConn.ClearNextHandler()
+		}
+
+		room.players[i] = nil
+	}
+
+	for _, spectator := range room.spectators {
+		spectator.pConn.ClearNextHandler()
+	}
+	room.spectators = nil
+	room.gameActive = false
+	room.closed = true
+
+	slog.Info("closing room", "room", room.uuid)
+
+	room.sendToNextHandler(EventRemoveRoom{
+		RoomUUID: room.uuid,
+	})
+}
+
+// handleClosed handles events that were sent before connections were detached from the room.
+// Disconnects go to the server, so it removes the connections, the rest is dropped.
+func (room *Room) handleClosed(e event.Event) {
+	if eSpectator, ok := e.(EventSpectator); ok {
+		e = eSpectator.Event
+	}
+
+	if e.GetType() == event.EventTypeDisconnect {
+		room.sendToNextHandler(e)
+	}
+}
+
+func (room *Room) sendRematchDeclined(player *Player, reason string) {
+	assert.NotNil(player, "player was nil")
+
+	msg := serverMsg.MakeMessage(serverMsg.TRematchDeclined, serverMsg.RematchDeclined{
+		Reason: reason,
+	})
+
+	room.sendMessage(player, msg)
 }
 
 // TODO: unit test
@@ -311,62 +1312,148 @@ func (room *Room) GetOpponent(playerId int) *Player {
 	return opponent
 }
 
-func (room *Room) gameEndWinHandler(winner, loser uuid.UUID) {
-	slog.Debug("game win", "room", room.uuid, "winner", winner)
+func (room *Room) gameEndWinHandler(winner, loser *Player, cause string) {
+	slog.Debug("game win", "room", room.uuid, "winner", winner.connectionID, "cause", cause)
 	
 	winMsg := serverMsg.MakeMessage(serverMsg.TWinEvent, &serverMsg.WinMessage{
 		Status: "win",
-		Cause: "",
+		Cause: cause,
+		Series: room.getSeries(winner),
+		Rating: room.ratingChanges[winner.playerID],
 	})
 
-	room.sendToNextHandler(EventSendMessage{
-		ConnectionId: winner,
-		Msg: winMsg,
-	})
+	room.sendMessage(winner, winMsg)
 	
 	loseMsg := serverMsg.MakeMessage(serverMsg.TWinEvent, &serverMsg.WinMessage{
 		Status: "lose",
-		Cause: "",
+		Cause: cause,
+		Series: room.getSeries(loser),
+		Rating: room.ratingChanges[loser.playerID],
 	})
 
-	room.sendToNextHandler(EventSendMessage{
-		ConnectionId: loser,
-		Msg: loseMsg,
-	})
+	room.sendMessage(loser, loseMsg)
 }
 
-func (room *Room) gameEndWinOnePlayerHandler(winner uuid.UUID) {
-	slog.Debug("game win", "room", room.uuid, "winner", winner)
+func (room *Room) gameEndWinOnePlayerHandler(winner *Player, cause string) {
+	slog.Debug("game win", "room", room.uuid, "winner", winner.connectionID, "cause", cause)
 	
 	winMsg := serverMsg.MakeMessage(serverMsg.TWinEvent, &serverMsg.WinMessage{
 		Status: "win",
-		Cause: "",
+		Cause: cause,
+		Series: room.getSeries(winner),
+		Rating: room.ratingChanges[winner.playerID],
 	})
 
-	room.sendToNextHandler(EventSendMessage{
-		ConnectionId: winner,
-		Msg: winMsg,
-	})
+	room.sendMessage(winner, winMsg)
 }
 
 
-func (room *Room) gameEndDrawHandler(c1, c2 uuid.UUID) {
-	slog.Debug("game draw", "room", room.uuid)
+func (room *Room) gameEndDrawHandler(p1, p2 *Player, cause string) {
+	slog.Debug("game draw", "room", room.uuid, "cause", cause)
 
-	drawMsg := serverMsg.MakeMessage(serverMsg.TWinEvent, &serverMsg.WinMessage{
-		Status: "draw",
-		Cause: "",
-	})
+	for _, player := range [2]*Player{p1, p2} {
+		drawMsg := serverMsg.MakeMessage(serverMsg.TWinEvent, &serverMsg.WinMessage{
+			Status: "draw",
+			Cause: cause,
+			Series: room.getSeries(player),
+			Rating: room.ratingChanges[player.playerID],
+		})
 
-	room.sendToNextHandler(EventSendMessage{
-		ConnectionId: c1,
-		Msg: drawMsg,
-	})
+		room.sendMessage(player, drawMsg)
+	}
+}
+
+// sendMessage sends message to the player's connection, or to the bot playing on this seat.
+func (room *Room) sendMessage(player *Player, msg message.Message) {
+	assert.NotNil(player, "player was nil")
+
+	e := EventSendMessage{
+		ConnectionId: player.connectionID,
+		Msg: msg,
+	}
+
+	// Connection of a held seat was removed, the player gets the state when it reconnects.
+	if !player.IsConnected() {
+		return
+	}
+
+	room.log.Add(matchLog.Outbound, player.playerID, serverMsg.MsgType(msg.Type).String(), msg.Data)
+
+	if player.IsBot() {
+		player.client.Handle(e)
+		return
+	}
+
+	room.sendToNextHandler(e)
+}
 
-	room.sendToNextHandler(EventSendMessage{
-		ConnectionId: c2,
-		Msg: drawMsg,
+func (room *Room) handleSpectatorJoin(eJoin EventSpectatorJoin) {
+	assert.NotNil(eJoin.Connection, "event spectator join connection was nil")
+	assert.NotNil(room.rules, "game rules was nil")
+
+	if len(room.spectators) >= MaxSpectators {
+		slog.Info("room is full of spectators", "room", room.uuid)
+
+		eJoin.Connection.sendNotAllowed("room has too many spectators")
+		return
+	}
+
+	spectator := CreateSpectator(room.sync, eJoin.Connection)
+	eJoin.Connection.SetNextHandler(spectator)
+	room.spectators = append(room.spectators, spectator)
+
+	slog.Debug("spectator joined", "room", room.uuid, "spectators", len(room.spectators))
+
+	room.sendSpectatorMessage(spectator, room.makeGameStateMessage(matchLog.NoSeat))
+}
+
+func (room *Room) handleSpectatorEvent(eSpectator EventSpectator) {
+	assert.NotNil(eSpectator.Spectator, "event spectator was nil")
+
+	if eSpectator.Event.GetType() == event.EventTypeDisconnect {
+		room.removeSpectator(eSpectator.Spectator)
+
+		// Server removes the connection.
+		room.sendToNextHandler(eSpectator.Event)
+		return
+	}
+
+	if eSpectator.Event.GetType() == event.EventTypeGameStateRequest {
+		room.sendSpectatorMessage(eSpectator.Spectator, room.makeGameStateMessage(matchLog.NoSeat))
+		return
+	}
+
+	msg := serverMsg.MakeMessage(serverMsg.TNotAllowedErr, &serverMsg.NotAllowedErrMessage{
+		Reason: "spectators cannot play",
 	})
+
+	room.sendSpectatorMessage(eSpectator.Spectator, msg)
+}
+
+func (room *Room) removeSpectator(spectator *Spectator) {
+	for i, s := range room.spectators {
+		if s == spectator {
+			room.spectators = append(room.spectators[:i], room.spectators[i + 1:]...)
+			return
+		}
+	}
+}
+
+func (room *Room) sendToSpectators(msg message.Message) {
+	for _, spectator := range room.spectators {
+		room.sendSpectatorMessage(spectator, msg)
+	}
+}
+
+func (room *Room) sendSpectatorMessage(spectator *Spectator, msg message.Message) {
+	assert.NotNil(spectator, "spectator was nil")
+
+	// Written straight to the connection, so spectators cannot fill the server sync channel,
+	// the connection serializes it with writes of its own loop.
+	// Closed connection is noticed by its loop, which reports the disconnect.
+	if spectator.pConn.GetConnection().SendMessage(msg) {
+		room.log.Add(matchLog.Outbound, matchLog.NoSeat, serverMsg.MsgType(msg.Type).String(), msg.Data)
+	}
 }
 
 func (room *Room) sendToNextHandler(e event.Event) {
@@ -375,8 +1462,13 @@ func (room *Room) sendToNextHandler(e event.Event) {
 	room.nextHandler.Handle(e)
 }
 
+// gameHasEnded is true also when the game ended off the board, e.g. on time.
 func (room *Room) gameHasEnded() bool {
-	assert.NotNil(room.game, "game was nil")
+	assert.NotNil(room.rules, "game rules was nil")
+
+	if room.record != nil && room.record.Result != record.ResultUnfinished {
+		return true
+	}
 
-	return room.game.GetWinState() != winState.Values.None
+	return room.rules.GetOutcome() != winState.Values.None
 }
\ No newline at end of file
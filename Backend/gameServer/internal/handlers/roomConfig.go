@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"GridPlay/gameRules"
+	"GridPlay/rating"
+	"time"
+)
+
+const DefaultReconnectGrace = 30 * time.Second
+
+// MaxBestOf is the longest series players can choose.
+const MaxBestOf = 9
+
+// DefaultPlayerName is given to players who connect without a name, their games are not ranked.
+const DefaultPlayerName = "guest"
+
+// RoomConfig are settings of a match, chosen when the room is created.
+type RoomConfig struct {
+	Params gameRules.Params
+	// How long the seat of a disconnected player is held, 0 means the player forfeits at once.
+	ReconnectGrace time.Duration
+	TimeControl TimeControl
+	// CreateRules creates rules of the next game in the room, nil disables rematch and series.
+	CreateRules func() (gameRules.GameRules, error)
+	// BestOf is the number of games in a series, the room is closed when a player clinches it.
+	// 0 or 1 is a single game.
+	BestOf int
+	// Ratings of the players are updated after every game, nil means the games are not ranked.
+	Ratings *rating.Ratings
+}
+
+func CreateRoomConfig(params gameRules.Params) RoomConfig {
+	return RoomConfig{
+		Params: params,
+		ReconnectGrace: DefaultReconnectGrace,
+	}
+}
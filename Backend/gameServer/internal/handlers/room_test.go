@@ -0,0 +1,411 @@
+package handlers
+
+import (
+	"GridPlay/engine"
+	"GridPlay/game"
+	"GridPlay/gameServer/internal/connection"
+	"GridPlay/gameServer/internal/event"
+	"GridPlay/gameServer/internal/matchLog"
+	"GridPlay/gameServer/message"
+	"GridPlay/gameServer/message/serverMsg"
+	"GridPlay/gameRules"
+	"GridPlay/record"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder stands for the server handler of rooms in tests, it keeps every event.
+type recorder struct {
+	events []event.Event
+}
+
+func (rec *recorder) Handle(e event.Event) {
+	rec.events = append(rec.events, e)
+}
+
+// takeMessages returns messages sent to the connection since the last call.
+func (rec *recorder) takeMessages(connId uuid.UUID) []message.Message {
+	var messages []message.Message
+	var rest []event.Event
+
+	for _, e := range rec.events {
+		eSend, ok := e.(EventSendMessage)
+
+		if ok && eSend.ConnectionId == connId {
+			messages = append(messages, eSend.Msg)
+		} else {
+			rest = append(rest, e)
+		}
+	}
+
+	rec.events = rest
+	return messages
+}
+
+// lastMessage returns the last message of the type sent to the connection, and forgets all of them.
+func (rec *recorder) lastMessage(t *testing.T, connId uuid.UUID, msgType serverMsg.MsgType) message.Message {
+	var last *message.Message
+
+	for _, msg := range rec.takeMessages(connId) {
+		if serverMsg.MsgType(msg.Type) == msgType {
+			last = &msg
+		}
+	}
+
+	require.NotNil(t, last, "no %s message", msgType)
+	return *last
+}
+
+func (rec *recorder) hasEvent(eType event.EventType) bool {
+	for _, e := range rec.events {
+		if e.GetType() == eType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// createTestConnection is a connection without socket, rooms send messages of players through the recorder.
+func createTestConnection(name string) *PlayerConnection {
+	return &PlayerConnection{
+		uuid: uuid.New(),
+		name: name,
+	}
+}
+
+// createSocketConnection is a connection with a socket, for spectators whose messages bypass the recorder.
+// Messages sent to it are read from the returned client socket.
+func createSocketConnection(t *testing.T) (*PlayerConnection, *websocket.Conn) {
+	sockets := make(chan *websocket.Conn, 1)
+	upgrader := websocket.Upgrader{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		socket, err := upgrader.Upgrade(w, r, nil)
+		if err == nil {
+			sockets <- socket
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws" + strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	pConn := &PlayerConnection{
+		uuid: uuid.New(),
+		connection: connection.CreateConnection(<-sockets),
+	}
+
+	return pConn, client
+}
+
+// readType returns type of the next message on the client socket.
+func readType(t *testing.T, client *websocket.Conn) serverMsg.MsgType {
+	client.SetReadDeadline(time.Now().Add(time.Second))
+
+	_, data, err := client.ReadMessage()
+	require.NoError(t, err)
+
+	var header message.MessageHeader
+	require.NoError(t, json.Unmarshal(data, &header))
+
+	return serverMsg.MsgType(header.Type)
+}
+
+func createTestRoom(t *testing.T, config RoomConfig) (*Room, *recorder, [2]*PlayerConnection) {
+	rules, err := game.CreateRules(nil)
+	require.NoError(t, err)
+
+	config.CreateRules = func() (gameRules.GameRules, error) {
+		return game.CreateRules(nil)
+	}
+
+	rec := &recorder{}
+	pConnections := [2]*PlayerConnection{createTestConnection("alice"), createTestConnection("bob")}
+	room := CreateRoom(rec, pConnections, uuid.New(), rules, config)
+
+	return room, rec, pConnections
+}
+
+// send passes the event from the connection to the room, as its loop does, and updates the room.
+func send(room *Room, pConn *PlayerConnection, e event.Event) {
+	pConn.Handle(e)
+	room.Update()
+}
+
+func TestGameStateAfterResignation(t *testing.T) {
+	room, rec, conns := createTestRoom(t, CreateRoomConfig(nil))
+	resigning := room.players[0].pConn
+
+	send(room, resigning, EventResign{})
+	send(room, conns[0], EventGameStateRequest{})
+	send(room, conns[1], EventGameStateRequest{})
+
+	for _, pConn := range conns {
+		msg := rec.lastMessage(t, pConn.uuid, serverMsg.TGameState)
+		state, ok := msg.Data.(serverMsg.GameState)
+		require.True(t, ok)
+
+		require.Equal(t, gameRules.Outcome{Status: "win", Winner: 1}, state.Outcome)
+	}
+}
+
+func TestRecordKeepsDecodedMove(t *testing.T) {
+	room, _, _ := createTestRoom(t, CreateRoomConfig(nil))
+	first := room.players[0].pConn
+
+	send(room, first, EventMove{Data: []byte(`{ "y": 1, "x": 2, "comment": "anything the client wants" }`)})
+
+	require.Len(t, room.record.Moves, 1)
+	require.JSONEq(t, `{"x": 2, "y": 1}`, string(room.record.Moves[0]))
+}
+
+func TestSpectatorCannotMove(t *testing.T) {
+	room, _, _ := createTestRoom(t, CreateRoomConfig(nil))
+	spectator, client := createSocketConnection(t)
+
+	room.AddSpectator(spectator)
+	room.Update()
+	require.Equal(t, serverMsg.TGameState, readType(t, client))
+
+	send(room, spectator, EventMove{Data: []byte(`{"x": 1, "y": 1}`)})
+
+	require.Equal(t, serverMsg.TNotAllowedErr, readType(t, client))
+	require.Equal(t, 0, room.rules.GetMoveNumber())
+}
+
+func TestResumeInsideGrace(t *testing.T) {
+	config := CreateRoomConfig(nil)
+	config.ReconnectGrace = time.Minute
+	room, rec, _ := createTestRoom(t, config)
+	first, second := room.players[0].pConn, room.players[1].pConn
+
+	send(room, first, EventDisconnect{ConnectionId: first.uuid})
+	rec.lastMessage(t, second.uuid, serverMsg.TOpponentConnection)
+
+	resumed := createTestConnection("alice")
+	room.Resume(resumed, room.resumeTokens[0])
+	room.Update()
+
+	rec.lastMessage(t, resumed.uuid, serverMsg.TMatchStarted)
+	rec.lastMessage(t, second.uuid, serverMsg.TOpponentConnection)
+
+	send(room, resumed, EventMove{Data: []byte(`{"x": 1, "y": 1}`)})
+	require.Equal(t, 1, room.rules.GetMoveNumber())
+}
+
+func TestResumeAfterGrace(t *testing.T) {
+	config := CreateRoomConfig(nil)
+	config.ReconnectGrace = time.Minute
+	room, rec, _ := createTestRoom(t, config)
+	first, second := room.players[0].pConn, room.players[1].pConn
+
+	send(room, first, EventDisconnect{ConnectionId: first.uuid})
+	room.players[0].disconnectedAt = time.Now().Add(-config.ReconnectGrace)
+	room.Update()
+
+	rec.lastMessage(t, second.uuid, serverMsg.TWinEvent)
+	require.Equal(t, record.ResultSecondWin, room.record.Result)
+
+	resumed := createTestConnection("alice")
+	room.Resume(resumed, room.resumeTokens[0])
+	room.Update()
+
+	rec.lastMessage(t, resumed.uuid, serverMsg.TNotAllowedErr)
+}
+
+func TestDrawOfferInterval(t *testing.T) {
+	room, rec, _ := createTestRoom(t, CreateRoomConfig(nil))
+	first, second := room.players[0].pConn, room.players[1].pConn
+
+	send(room, first, EventDrawOffer{})
+	rec.lastMessage(t, second.uuid, serverMsg.TDrawOffer)
+
+	send(room, second, EventDrawAnswer{Accept: false})
+	rec.lastMessage(t, first.uuid, serverMsg.TDrawDeclined)
+
+	send(room, first, EventDrawOffer{})
+	msg := rec.lastMessage(t, first.uuid, serverMsg.TDrawDeclined)
+	require.Equal(t, serverMsg.DrawDeclined{Reason: "draw was offered too recently"}, msg.Data)
+	require.Empty(t, rec.takeMessages(second.uuid))
+
+	room.drawOfferedAt[0] = time.Now().Add(-DrawOfferInterval)
+
+	send(room, first, EventDrawOffer{})
+	rec.lastMessage(t, second.uuid, serverMsg.TDrawOffer)
+}
+
+func TestRematchSwapsSeats(t *testing.T) {
+	// Symbols are random in new rules, the room must not let players keep theirs in either case.
+	for _, keepsSymbol := range []bool{false, true} {
+		testRematchSwapsSeats(t, keepsSymbol)
+	}
+}
+
+func testRematchSwapsSeats(t *testing.T, keepsSymbol bool) {
+	room, rec, _ := createTestRoom(t, CreateRoomConfig(nil))
+	first, second := room.players[0].pConn, room.players[1].pConn
+	symbols := [2]rune{room.rules.GetPlayerSymbol(0), room.rules.GetPlayerSymbol(1)}
+	tokens := room.resumeTokens
+
+	// keepsSymbol gives the second seat of the next game to the symbol of the first player, who moves there.
+	room.config.CreateRules = func() (gameRules.GameRules, error) {
+		rules, err := game.CreateRules(nil)
+		require.NoError(t, err)
+
+		if (rules.GetPlayerSymbol(1) == symbols[0]) != keepsSymbol {
+			rules.(gameRules.SymbolSwapper).SwapSymbols()
+		}
+
+		return rules, nil
+	}
+
+	send(room, first, EventResign{})
+	send(room, first, EventRematchRequest{})
+	rec.lastMessage(t, second.uuid, serverMsg.TRematchRequest)
+	send(room, second, EventRematchAnswer{Accept: true})
+
+	require.Equal(t, second, room.players[0].pConn)
+	require.Equal(t, first, room.players[1].pConn)
+	require.Equal(t, [2]string{tokens[1], tokens[0]}, room.resumeTokens)
+
+	msg := rec.lastMessage(t, second.uuid, serverMsg.TMatchStarted)
+	require.Equal(t, &serverMsg.MatchStarted{
+		Game: room.rules.GetName(),
+		Char: symbols[0],
+		OpponentChar: symbols[1],
+		ResumeToken: tokens[1],
+		Series: serverMsg.Series{BestOf: 1, Games: 1, Score: 1, OpponentScore: 0},
+	}, msg.Data)
+
+	msg = rec.lastMessage(t, first.uuid, serverMsg.TMatchStarted)
+	require.Equal(t, &serverMsg.MatchStarted{
+		Game: room.rules.GetName(),
+		Char: symbols[1],
+		OpponentChar: symbols[0],
+		ResumeToken: tokens[0],
+		Series: serverMsg.Series{BestOf: 1, Games: 1, Score: 0, OpponentScore: 1},
+	}, msg.Data)
+}
+
+func TestSeriesClinched(t *testing.T) {
+	config := CreateRoomConfig(nil)
+	config.BestOf = 3
+	room, rec, _ := createTestRoom(t, config)
+	first, second := room.players[0].pConn, room.players[1].pConn
+
+	send(room, first, EventResign{})
+	require.False(t, rec.hasEvent(event.EventTypeRemoveRoom))
+	require.Equal(t, second, room.players[0].pConn)
+
+	send(room, first, EventResign{})
+
+	msg := rec.lastMessage(t, second.uuid, serverMsg.TSeriesEnd)
+	require.Equal(t, serverMsg.SeriesEnd{
+		Status: "win",
+		Series: serverMsg.Series{BestOf: 3, Games: 2, Score: 2, OpponentScore: 0},
+	}, msg.Data)
+
+	msg = rec.lastMessage(t, first.uuid, serverMsg.TSeriesEnd)
+	require.Equal(t, "lose", msg.Data.(serverMsg.SeriesEnd).Status)
+
+	require.True(t, rec.hasEvent(event.EventTypeRemoveRoom))
+}
+
+func TestSeriesForfeitClosesRoom(t *testing.T) {
+	config := CreateRoomConfig(nil)
+	config.BestOf = 3
+	config.ReconnectGrace = 0
+	room, rec, _ := createTestRoom(t, config)
+	first, second := room.players[0].pConn, room.players[1].pConn
+
+	send(room, first, EventDisconnect{ConnectionId: first.uuid})
+
+	msg := rec.lastMessage(t, second.uuid, serverMsg.TSeriesEnd)
+	require.Equal(t, "win", msg.Data.(serverMsg.SeriesEnd).Status)
+	require.True(t, rec.hasEvent(event.EventTypeRemoveRoom))
+}
+
+// blockingEngine searches until the release channel is closed, so searches overlap in tests.
+type blockingEngine struct {
+	release chan struct{}
+	searches atomic.Int32
+}
+
+func (e *blockingEngine) ChooseMove(state gameRules.Searchable) gameRules.Move {
+	e.searches.Add(1)
+	<-e.release
+
+	return state.GetLegalMoves()[0]
+}
+
+func TestBotSearchOfPreviousGameHasOwnEngine(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	var engines []*blockingEngine
+	createEngine := func() engine.Engine {
+		e := &blockingEngine{release: release}
+		engines = append(engines, e)
+		return e
+	}
+
+	config := CreateRoomConfig(nil)
+	config.CreateRules = func() (gameRules.GameRules, error) {
+		return game.CreateRules(nil)
+	}
+	rules, err := config.CreateRules()
+	require.NoError(t, err)
+
+	human := createTestConnection("alice")
+	room := CreateBotRoom(&recorder{}, human, uuid.New(), rules.(gameRules.Searchable), config, createEngine)
+
+	// Bot starts searching on its move, the human moves first if it is on the move.
+	startSearch := func() {
+		if !room.players[room.rules.GetCurrentPlayer()].IsBot() {
+			send(room, human, EventMove{Data: []byte(`{"x": 1, "y": 1}`)})
+		}
+
+		last := engines[len(engines) - 1]
+		require.Eventually(t, func() bool { return last.searches.Load() == 1 }, time.Second, time.Millisecond)
+	}
+
+	startSearch()
+	send(room, human, EventResign{})
+	send(room, human, EventRematchRequest{})
+	// Bot accepts the rematch through the room sync.
+	room.Update()
+
+	require.Equal(t, 1, room.gamesPlayed)
+	require.Len(t, engines, 2)
+
+	startSearch()
+	require.Equal(t, int32(1), engines[0].searches.Load())
+}
+
+func TestMessagesToHeldSeatAreNotLogged(t *testing.T) {
+	config := CreateRoomConfig(nil)
+	config.ReconnectGrace = time.Minute
+	room, _, _ := createTestRoom(t, config)
+	first, second := room.players[0].pConn, room.players[1].pConn
+
+	send(room, first, EventMove{Data: []byte(`{"x": 1, "y": 1}`)})
+	send(room, first, EventDisconnect{ConnectionId: first.uuid})
+	entries := len(room.log.GetEntries())
+
+	send(room, second, EventMove{Data: []byte(`{"x": 0, "y": 0}`)})
+
+	for _, entry := range room.log.GetEntries()[entries:] {
+		require.False(t, entry.Direction == matchLog.Outbound && entry.Seat == 0, "logged %s to held seat", entry.Type)
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"GridPlay/assert"
+	"GridPlay/gameServer/internal/event"
+	"log/slog"
+)
+
+// Spectator watches a room. It receives the game state and every move,
+// but all of its events are only reported to the room, which rejects them.
+type Spectator struct {
+	nextHandler Handler
+	pConn *PlayerConnection
+}
+
+func CreateSpectator(nextHandler Handler, pConn *PlayerConnection) *Spectator {
+	assert.NotNil(nextHandler, "next handler was nil")
+	assert.NotNil(pConn, "player connection was nil")
+
+	return &Spectator{
+		nextHandler: nextHandler,
+		pConn: pConn,
+	}
+}
+
+func (spectator *Spectator) Handle(e event.Event) {
+	assert.NotNil(spectator.nextHandler, "spectator next handler was nil")
+
+	slog.Debug("event in spectator", "Type", e.GetType(), "event", e)
+
+	spectator.nextHandler.Handle(EventSpectator{
+		Spectator: spectator,
+		Event: e,
+	})
+}
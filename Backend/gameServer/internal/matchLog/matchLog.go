@@ -0,0 +1,66 @@
+package matchLog
+
+import (
+	"slices"
+	"time"
+)
+
+type Direction string
+
+const (
+	// Inbound events come from players to the room.
+	Inbound Direction = "in"
+	// Outbound messages are sent by the room to players.
+	Outbound Direction = "out"
+)
+
+// NoSeat marks entries which do not belong to any player.
+const NoSeat = -1
+
+// MaxEntries limits the log of one room, the oldest entries are dropped so the end of the match is kept.
+const MaxEntries = 10000
+
+type Entry struct {
+	At time.Time `json:"at"`
+	Direction Direction `json:"direction"`
+	Seat int `json:"seat"`
+	Type string `json:"type"`
+	Data any `json:"data,omitempty"`
+}
+
+// Log of everything that happened in a room. It is written only by the room
+// and must not be changed after the room was removed.
+type Log struct {
+	entries []Entry
+	// Index of the oldest entry, entries are overwritten from it once the log is full.
+	oldest int
+}
+
+func CreateLog() *Log {
+	return &Log{
+		entries: []Entry{},
+	}
+}
+
+func (log *Log) Add(direction Direction, seat int, eventType string, data any) {
+	entry := Entry{
+		At: time.Now(),
+		Direction: direction,
+		Seat: seat,
+		Type: eventType,
+		Data: data,
+	}
+
+	if len(log.entries) < MaxEntries {
+		log.entries = append(log.entries, entry)
+		return
+	}
+
+	log.entries[log.oldest] = entry
+	log.oldest = (log.oldest + 1) % MaxEntries
+}
+
+// GetEntries returns entries from the oldest one.
+func (log *Log) GetEntries() []Entry {
+	return append(slices.Clone(log.entries[log.oldest:]), log.entries[:log.oldest]...)
+}
@@ -0,0 +1,20 @@
+package matchLog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogKeepsLastEntries(t *testing.T) {
+	log := CreateLog()
+
+	for i := range MaxEntries + 10 {
+		log.Add(Inbound, NoSeat, "move", i)
+	}
+
+	entries := log.GetEntries()
+	require.Len(t, entries, MaxEntries)
+	require.Equal(t, 10, entries[0].Data)
+	require.Equal(t, MaxEntries + 9, entries[MaxEntries - 1].Data)
+}
@@ -0,0 +1,75 @@
+package replay
+
+import (
+	"log/slog"
+	"time"
+
+	"GridPlay/assert"
+	"GridPlay/gameServer/internal/connection"
+	"GridPlay/gameServer/internal/matchLog"
+	"GridPlay/gameServer/message/serverMsg"
+)
+
+const (
+	DefaultSpeed = 1.0
+	MaxSpeed = 100.0
+
+	// closeTimeout is how long the viewer has to answer the close message.
+	closeTimeout = 3 * time.Second
+)
+
+// Stream sends entries of the log to the viewer as replay_entry messages, pauses
+// between entries are the original ones divided by speed. Connection is closed
+// when the log ends or the viewer leaves.
+func Stream(conn *connection.Connection, log *matchLog.Log, speed float64) {
+	assert.NotNil(conn, "connection was nil")
+	assert.NotNil(log, "log was nil")
+	assert.Assert(speed > 0 && speed <= MaxSpeed, "replay speed out of range", "speed", speed)
+
+	conn.StartReceiving()
+	entries := log.GetEntries()
+
+	for i, entry := range entries {
+		if i > 0 {
+			pause := time.Duration(float64(entry.At.Sub(entries[i - 1].At)) / speed)
+
+			if !wait(conn, pause) {
+				return
+			}
+		}
+
+		conn.SendMessage(serverMsg.MakeMessage(serverMsg.TReplayEntry, entry))
+
+		if conn.GetLastError() != nil {
+			slog.Info("cannot send replay entry", "ip", conn.GetRemoteIP(), "err", conn.GetLastError())
+			break
+		}
+	}
+
+	conn.StopReceiving()
+
+	// Receiving stops when the viewer answers the close message, otherwise the socket is closed.
+	if wait(conn, closeTimeout) {
+		conn.Close()
+
+		for wait(conn, closeTimeout) {
+		}
+	}
+}
+
+// wait returns false if the viewer has left before the pause ended.
+func wait(conn *connection.Connection, pause time.Duration) bool {
+	timer := time.NewTimer(pause)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <- timer.C:
+			return true
+		case <- conn.GetMessageFromClient():
+			// Viewers only watch, their messages are ignored.
+		case <- conn.GetExitChan():
+			return false
+		}
+	}
+}
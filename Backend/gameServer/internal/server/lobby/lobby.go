@@ -0,0 +1,93 @@
+package lobby
+
+import (
+	"GridPlay/gameServer/internal/handlers"
+	"GridPlay/gameServer/internal/server/serverData"
+	"GridPlay/gameServer/message/serverMsg"
+	"reflect"
+
+	"github.com/google/uuid"
+)
+
+// Lobby keeps connections subscribed to live updates of the room list.
+// It is not safe for concurrent use, the server uses it only from its update.
+type Lobby struct {
+	subscribers map[uuid.UUID]bool
+	// Last list sent to the subscribers.
+	rooms []serverMsg.LobbyRoom
+}
+
+func CreateLobby() *Lobby {
+	return &Lobby{
+		subscribers: map[uuid.UUID]bool{},
+		rooms: []serverMsg.LobbyRoom{},
+	}
+}
+
+func (lobby *Lobby) Subscribe(id uuid.UUID) {
+	lobby.subscribers[id] = true
+}
+
+func (lobby *Lobby) Unsubscribe(id uuid.UUID) {
+	delete(lobby.subscribers, id)
+}
+
+func (lobby *Lobby) HasSubscribers() bool {
+	return len(lobby.subscribers) > 0
+}
+
+func (lobby *Lobby) GetSubscribers() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(lobby.subscribers))
+
+	for id := range lobby.subscribers {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// Update stores the room list and tells if it has changed since the last one.
+func (lobby *Lobby) Update(rooms []serverMsg.LobbyRoom) bool {
+	if reflect.DeepEqual(lobby.rooms, rooms) {
+		return false
+	}
+
+	lobby.rooms = rooms
+	return true
+}
+
+// CreateRooms lists open rooms first, they are waiting for an opponent.
+func CreateRooms(invites []serverData.Invite, infos []handlers.RoomInfo) []serverMsg.LobbyRoom {
+	rooms := make([]serverMsg.LobbyRoom, 0, len(invites) + len(infos))
+
+	for _, invite := range invites {
+		rooms = append(rooms, serverMsg.LobbyRoom{
+			ID: invite.ID.String(),
+			Game: invite.Game,
+			Params: invite.Params,
+			Host: invite.HostName,
+			Open: true,
+			BestOf: max(invite.BestOf, 1),
+			CreatedAt: invite.CreatedAt,
+		})
+	}
+
+	for _, info := range infos {
+		host := info.Players[0]
+		if host == "" {
+			host = info.Players[1]
+		}
+
+		rooms = append(rooms, serverMsg.LobbyRoom{
+			ID: info.UUID.String(),
+			Game: info.Game,
+			Params: info.Params,
+			Host: host,
+			Spectators: info.Spectators,
+			BestOf: info.BestOf,
+			CreatedAt: info.CreatedAt,
+		})
+	}
+
+	return rooms
+}
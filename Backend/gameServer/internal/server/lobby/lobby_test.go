@@ -0,0 +1,37 @@
+package lobby
+
+import (
+	"GridPlay/gameServer/internal/handlers"
+	"GridPlay/gameServer/internal/server/serverData"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRooms(t *testing.T) {
+	now := time.Now()
+	invite := serverData.Invite{ID: uuid.New(), HostName: "alice", Game: "tictactoe", CreatedAt: now}
+	info := handlers.RoomInfo{UUID: uuid.New(), Game: "connect4", Players: [2]string{"", "bob"}, Spectators: 2, CreatedAt: now}
+
+	rooms := CreateRooms([]serverData.Invite{invite}, []handlers.RoomInfo{info})
+
+	require.Len(t, rooms, 2)
+	require.Equal(t, invite.ID.String(), rooms[0].ID)
+	require.Equal(t, "alice", rooms[0].Host)
+	require.True(t, rooms[0].Open)
+	require.Equal(t, "bob", rooms[1].Host)
+	require.False(t, rooms[1].Open)
+	require.Equal(t, 2, rooms[1].Spectators)
+}
+
+func TestUpdate(t *testing.T) {
+	lobby := CreateLobby()
+	invite := serverData.Invite{ID: uuid.New(), HostName: "alice", Game: "tictactoe", CreatedAt: time.Now()}
+
+	require.False(t, lobby.Update(CreateRooms(nil, nil)))
+	require.True(t, lobby.Update(CreateRooms([]serverData.Invite{invite}, nil)))
+	require.False(t, lobby.Update(CreateRooms([]serverData.Invite{invite}, nil)))
+	require.True(t, lobby.Update(CreateRooms(nil, nil)))
+}
@@ -2,14 +2,22 @@ package matchmaker
 
 import (
 	"GridPlay/gameServer/internal/event"
-
-	"github.com/google/uuid"
 )
 
+// EventPlayersMatched Requests have the same game, params and time control.
 type EventPlayersMatched struct {
-	Ids [2]uuid.UUID
+	Requests [2]Request
 }
 
 func (e EventPlayersMatched) GetType() event.EventType {
 	return event.EventTypePlayersMatched
-}
\ No newline at end of file
+}
+
+// EventBotMatched is sent when a player waited too long for an opponent.
+type EventBotMatched struct {
+	Request Request
+}
+
+func (e EventBotMatched) GetType() event.EventType {
+	return event.EventTypeBotMatched
+}
@@ -5,13 +5,23 @@ import (
 	"GridPlay/gameServer/internal/event"
 	"GridPlay/gameServer/internal/server"
 	"GridPlay/gameServer/internal/server/serverEvents"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// Time after which a lone player is matched with a bot.
+const DefaultBotWaitTime = 20 * time.Second
+
+// Waiting players are matched on every tick.
+const DefaultTickInterval = 200 * time.Millisecond
+
 type Matchmaker struct {
 	mediator server.Mediator
-	matcher chan uuid.UUID
+	matcher chan Request
+	remover chan uuid.UUID
+	botWaitTime time.Duration
+	tickInterval time.Duration
 	isLoopRunning bool
 	stopLoop chan bool
 }
@@ -21,7 +31,11 @@ func CreateMatchMaker(mediator server.Mediator) *Matchmaker {
 
 	return &Matchmaker{
 		mediator: mediator,
-		matcher: make(chan uuid.UUID, 2),
+		matcher: make(chan Request, 2),
+		remover: make(chan uuid.UUID, 2),
+		botWaitTime: DefaultBotWaitTime,
+		tickInterval: DefaultTickInterval,
+		stopLoop: make(chan bool),
 	}
 }
 
@@ -39,35 +53,70 @@ func (mmaker *Matchmaker) EndLoop() {
 	mmaker.isLoopRunning = false
 }
 
-func (mmaker *Matchmaker) Add(uuid uuid.UUID) {
-	mmaker.matcher <- uuid
+// Add puts the player in the queue of the request, it leaves the queue it was in.
+func (mmaker *Matchmaker) Add(request Request) {
+	select {
+	case mmaker.matcher <- request:
+	default:
+		// Mediator adds players back from within the loop when their opponent has left,
+		// so it must not wait for the loop.
+		go func() {
+			mmaker.matcher <- request
+		}()
+	}
+}
+
+// Remove takes the player out of the queue, e.g. when it hosts a private room.
+func (mmaker *Matchmaker) Remove(uuid uuid.UUID) {
+	mmaker.remover <- uuid
 }
 
+// loop collects requests in the pool, they are matched on ticks.
 func (mmaker *Matchmaker) loop() {
-	ids := make([]uuid.UUID, 0, 2)
+	queue := createPool(mmaker.botWaitTime)
+	ticker := time.NewTicker(mmaker.tickInterval)
+	defer ticker.Stop()
 
 	for {
 		select {
-		case id := <-mmaker.matcher:
-			assert.Assert(len(ids) < 2, "wrong ids length")
-			ids = append(ids, id)
-
-			if len(ids) == 2 {
-				mmaker.match(ids)
-				ids = nil
-			} 
+		case request := <-mmaker.matcher:
+			queue.add(request, time.Now())
+		case id := <-mmaker.remover:
+			queue.remove(id)
+		case now := <-ticker.C:
+			if queue.size() == 0 {
+				continue
+			}
+
+			pairs, lonely := queue.takeMatches(now)
+
+			for _, pair := range pairs {
+				mmaker.match(pair[0], pair[1])
+			}
+
+			for _, request := range lonely {
+				mmaker.matchWithBot(request)
+			}
 		case <-mmaker.stopLoop:
 			return
 		}
 	}
 }
 
-func (mmaker *Matchmaker) match(ids []uuid.UUID) {
-	assert.Assert(len(ids) == 2, "wrong ids length")
+func (mmaker *Matchmaker) match(first, second Request) {
+	assert.Assert(first.key() == second.key(), "requests cannot be paired")
 
 	mmaker.notifyMediator(
 		EventPlayersMatched{
-			[2]uuid.UUID{ids[0], ids[1]},
+			Requests: [2]Request{first, second},
+		},
+	)
+}
+
+func (mmaker *Matchmaker) matchWithBot(request Request) {
+	mmaker.notifyMediator(
+		EventBotMatched{
+			Request: request,
 		},
 	)
 }
@@ -79,4 +128,4 @@ func (mmaker *Matchmaker) notifyMediator(e event.Event) {
 		Sender: serverEvents.Matchmaker,
 		Event: e,
 	})
-}
\ No newline at end of file
+}
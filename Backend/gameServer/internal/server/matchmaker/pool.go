@@ -0,0 +1,117 @@
+package matchmaker
+
+import (
+	"GridPlay/rating"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Rating of players who have not played rated games yet.
+const DefaultRating = rating.DefaultValue
+
+// Rating window starts narrow and widens the longer a player waits, up to the maximum.
+const (
+	InitialRatingWindow = 50.0
+	// Points added to the window every second.
+	RatingWindowGrowth = 10.0
+	MaxRatingWindow = 400.0
+)
+
+// ratingWindow is the largest rating difference to an opponent, after waiting for wait.
+func ratingWindow(wait time.Duration) float64 {
+	return math.Min(InitialRatingWindow + RatingWindowGrowth * wait.Seconds(), MaxRatingWindow)
+}
+
+// waiting is a request without an opponent yet.
+type waiting struct {
+	request Request
+	since time.Time
+}
+
+// pool keeps waiting requests in the order they came, the oldest are matched first.
+type pool struct {
+	entries []waiting
+	botWaitTime time.Duration
+}
+
+func createPool(botWaitTime time.Duration) *pool {
+	return &pool{
+		entries: []waiting{},
+		botWaitTime: botWaitTime,
+	}
+}
+
+// add replaces the request of the same player, so it waits again from now.
+func (p *pool) add(request Request, now time.Time) {
+	p.remove(request.Id)
+	p.entries = append(p.entries, waiting{request: request, since: now})
+}
+
+func (p *pool) remove(id uuid.UUID) {
+	for i, w := range p.entries {
+		if w.request.Id == id {
+			p.entries = append(p.entries[:i], p.entries[i + 1:]...)
+			return
+		}
+	}
+}
+
+func (p *pool) size() int {
+	return len(p.entries)
+}
+
+// takeMatches removes pairs of compatible requests and requests which waited too long for an opponent.
+// Every waiting player, from the oldest, gets the opponent with the closest rating within the window
+// of the player or of the opponent, whichever waited longer.
+func (p *pool) takeMatches(now time.Time) ([][2]Request, []Request) {
+	pairs := [][2]Request{}
+	lonely := []Request{}
+	taken := make([]bool, len(p.entries))
+
+	for i, w := range p.entries {
+		if taken[i] {
+			continue
+		}
+
+		opponent := -1
+		closest := math.Inf(1)
+
+		for j := i + 1; j < len(p.entries); j++ {
+			other := p.entries[j]
+
+			if taken[j] || other.request.key() != w.request.key() {
+				continue
+			}
+
+			diff := math.Abs(w.request.Rating - other.request.Rating)
+			// Entries are in order of arrival, so w has waited longer.
+			window := ratingWindow(now.Sub(w.since))
+
+			if diff <= window && diff < closest {
+				opponent = j
+				closest = diff
+			}
+		}
+
+		if opponent >= 0 {
+			taken[i], taken[opponent] = true, true
+			pairs = append(pairs, [2]Request{w.request, p.entries[opponent].request})
+		} else if now.Sub(w.since) >= p.botWaitTime {
+			taken[i] = true
+			lonely = append(lonely, w.request)
+		}
+	}
+
+	remaining := []waiting{}
+
+	for i, w := range p.entries {
+		if !taken[i] {
+			remaining = append(remaining, w)
+		}
+	}
+
+	p.entries = remaining
+	return pairs, lonely
+}
@@ -0,0 +1,86 @@
+package matchmaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createRequest(game string, rating float64) Request {
+	return Request{Id: uuid.New(), Game: game, Rating: rating}
+}
+
+func TestRatingWindow(t *testing.T) {
+	require.Equal(t, InitialRatingWindow, ratingWindow(0))
+	require.Equal(t, InitialRatingWindow + 5 * RatingWindowGrowth, ratingWindow(5 * time.Second))
+	require.Equal(t, MaxRatingWindow, ratingWindow(time.Hour))
+}
+
+func TestPoolWideningWindow(t *testing.T) {
+	start := time.Now()
+	p := createPool(time.Minute)
+
+	strong := createRequest("tictactoe", 1700)
+	weak := createRequest("tictactoe", 1500)
+	p.add(strong, start)
+	p.add(weak, start)
+
+	pairs, lonely := p.takeMatches(start)
+	require.Empty(t, pairs)
+	require.Empty(t, lonely)
+
+	// Window is 200 after 15 seconds.
+	pairs, _ = p.takeMatches(start.Add(15 * time.Second))
+	require.Equal(t, [][2]Request{{strong, weak}}, pairs)
+	require.Equal(t, 0, p.size())
+}
+
+func TestPoolClosestRating(t *testing.T) {
+	now := time.Now()
+	p := createPool(time.Minute)
+
+	first := createRequest("tictactoe", 1500)
+	far := createRequest("tictactoe", 1540)
+	other := createRequest("gomoku", 1500)
+	close := createRequest("tictactoe", 1510)
+
+	p.add(first, now)
+	p.add(far, now)
+	p.add(other, now)
+	p.add(close, now)
+
+	pairs, _ := p.takeMatches(now)
+	require.Equal(t, [][2]Request{{first, close}}, pairs)
+	require.Equal(t, 2, p.size())
+}
+
+func TestPoolBotTimeout(t *testing.T) {
+	start := time.Now()
+	p := createPool(20 * time.Second)
+
+	request := createRequest("tictactoe", 1500)
+	p.add(request, start)
+	p.add(createRequest("gomoku", 1500), start.Add(time.Second))
+
+	_, lonely := p.takeMatches(start.Add(20 * time.Second))
+	require.Equal(t, []Request{request}, lonely)
+	require.Equal(t, 1, p.size())
+}
+
+func TestPoolReadd(t *testing.T) {
+	now := time.Now()
+	p := createPool(time.Minute)
+
+	request := createRequest("tictactoe", 1500)
+	p.add(request, now)
+	p.remove(uuid.New())
+
+	request.Game = "gomoku"
+	p.add(request, now)
+	require.Equal(t, 1, p.size())
+
+	p.remove(request.Id)
+	require.Equal(t, 0, p.size())
+}
@@ -0,0 +1,37 @@
+package matchmaker
+
+import (
+	"GridPlay/gameRules"
+	"GridPlay/gameServer/internal/handlers"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Request of a player waiting for an opponent.
+type Request struct {
+	Id uuid.UUID
+	Game string
+	Params gameRules.Params
+	TimeControl handlers.TimeControl
+	BestOf int
+	// Rating of the player in the game, opponents are chosen by it.
+	Rating float64
+}
+
+// key is the same for requests which can be paired: same game, params, time control and series length.
+// Nil params are the same as empty params.
+func (request Request) key() string {
+	pairs := make([]string, 0, len(request.Params))
+
+	for name, value := range request.Params {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", name, value))
+	}
+
+	sort.Strings(pairs)
+	control := request.TimeControl
+
+	return fmt.Sprintf("%s %s %v+%v/%v bo%d", request.Game, strings.Join(pairs, ","), control.Total, control.Increment, control.MoveTime, request.BestOf)
+}
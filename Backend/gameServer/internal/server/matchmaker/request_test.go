@@ -0,0 +1,28 @@
+package matchmaker
+
+import (
+	"GridPlay/gameRules"
+	"GridPlay/gameServer/internal/handlers"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestKey(t *testing.T) {
+	request := Request{Game: "gomoku", Params: gameRules.Params{"width": 15, "height": 15}}
+
+	require.Equal(t, request.key(), Request{Game: "gomoku", Params: gameRules.Params{"height": 15, "width": 15}}.key())
+	require.Equal(t, Request{Game: "tictactoe"}.key(), Request{Game: "tictactoe", Params: gameRules.Params{}}.key())
+
+	require.NotEqual(t, request.key(), Request{Game: "gomoku", Params: gameRules.Params{"width": 19, "height": 15}}.key())
+	require.NotEqual(t, request.key(), Request{Game: "tictactoe", Params: request.Params}.key())
+
+	timed := request
+	timed.TimeControl = handlers.TimeControl{Total: time.Minute}
+	require.NotEqual(t, request.key(), timed.key())
+
+	series := request
+	series.BestOf = 3
+	require.NotEqual(t, request.key(), series.key())
+}
@@ -2,15 +2,25 @@ package mediator
 
 import (
 	"GridPlay/assert"
+	"GridPlay/engine"
+	"GridPlay/gameRules"
 	"GridPlay/gameServer/internal/connection"
 	"GridPlay/gameServer/internal/event"
 	"GridPlay/gameServer/internal/handlers"
+	"GridPlay/gameServer/internal/matchLog"
+	"GridPlay/gameServer/internal/server/lobby"
 	"GridPlay/gameServer/internal/server/matchmaker"
 	"GridPlay/gameServer/internal/server/serverData"
 	"GridPlay/gameServer/internal/server/serverEvents"
 	"GridPlay/gameServer/message"
 	"GridPlay/gameServer/message/serverMsg"
+	"GridPlay/rating"
+	"GridPlay/record"
+	"GridPlay/store"
+	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -19,14 +29,40 @@ type ServerMediator struct {
 	handler *handlers.ServerHandler
 	matchmaker *matchmaker.Matchmaker
 	serverData *serverData.ServerData
+	lobby *lobby.Lobby
+	games *gameRules.Registry
+	records *store.Store[*record.Record]
+	logs *store.Store[*matchLog.Log]
+	ratings *rating.Ratings
+	reconnectGrace time.Duration
+	inviteTimeout time.Duration
+	// Time control of every game, games without one have no time limit.
+	timeControls map[string]handlers.TimeControl
+	// Series length of every game, games without one play single games.
+	bestOf map[string]int
 }
 
-func CreateServerMediator() *ServerMediator {
-	mediator := &ServerMediator{}
+func CreateServerMediator(games *gameRules.Registry, records *store.Store[*record.Record], logs *store.Store[*matchLog.Log], ratings *rating.Ratings) *ServerMediator {
+	assert.NotNil(games, "game registry was nil")
+	assert.NotNil(records, "record store was nil")
+	assert.NotNil(logs, "log store was nil")
+	assert.NotNil(ratings, "ratings was nil")
+
+	mediator := &ServerMediator{
+		games: games,
+		records: records,
+		logs: logs,
+		ratings: ratings,
+		reconnectGrace: handlers.DefaultReconnectGrace,
+		inviteTimeout: serverData.DefaultInviteTimeout,
+		timeControls: map[string]handlers.TimeControl{},
+		bestOf: map[string]int{},
+	}
 
 	mediator.handler = handlers.CreateServerHandler(mediator)
 	mediator.matchmaker = matchmaker.CreateMatchMaker(mediator)
 	mediator.serverData = serverData.CreateServerData()
+	mediator.lobby = lobby.CreateLobby()
 
 	return mediator
 }
@@ -83,6 +119,55 @@ func (mediator *ServerMediator) FromServerHandler(e event.Event) bool {
 		assert.Assert(ok, "type assertion failed for event remove room")
 
 		mediator.RemoveRoom(eRemoveRoom.RoomUUID)
+
+	case event.EventTypeGameRecord:
+		eGameRecord, ok := e.(handlers.EventGameRecord)
+		assert.Assert(ok, "type assertion failed for event game record")
+		assert.NotNil(mediator.records, "record store was nil")
+
+		mediator.records.Add(eGameRecord.RecordUUID, eGameRecord.Record)
+
+	case event.EventTypeCreatePrivateRoom:
+		eCreate, ok := e.(handlers.EventCreatePrivateRoom)
+		assert.Assert(ok, "type assertion failed for event create private room")
+
+		mediator.CreatePrivateRoom(eCreate.ConnectionId, eCreate.Choice)
+
+	case event.EventTypeJoinPrivateRoom:
+		eJoin, ok := e.(handlers.EventJoinPrivateRoom)
+		assert.Assert(ok, "type assertion failed for event join private room")
+
+		mediator.JoinPrivateRoom(eJoin.ConnectionId, eJoin.Code)
+
+	case event.EventTypeOpenRoom:
+		eOpen, ok := e.(handlers.EventOpenRoom)
+		assert.Assert(ok, "type assertion failed for event open room")
+
+		mediator.OpenRoom(eOpen.ConnectionId, eOpen.Choice)
+
+	case event.EventTypeJoinRoom:
+		eJoin, ok := e.(handlers.EventJoinRoom)
+		assert.Assert(ok, "type assertion failed for event join room")
+
+		mediator.JoinRoom(eJoin.ConnectionId, eJoin.RoomId)
+
+	case event.EventTypeLobbyRequest:
+		eRequest, ok := e.(handlers.EventLobbyRequest)
+		assert.Assert(ok, "type assertion failed for event lobby request")
+
+		mediator.SendLobby(eRequest.ConnectionId)
+
+	case event.EventTypeLobbySubscribe:
+		eSubscribe, ok := e.(handlers.EventLobbySubscribe)
+		assert.Assert(ok, "type assertion failed for event lobby subscribe")
+
+		mediator.SubscribeLobby(eSubscribe.ConnectionId, eSubscribe.Subscribe)
+
+	case event.EventTypeEnqueue:
+		eEnqueue, ok := e.(handlers.EventEnqueue)
+		assert.Assert(ok, "type assertion failed for event enqueue")
+
+		mediator.Enqueue(eEnqueue.ConnectionId, eEnqueue.Choice)
 	default:
 		return false
 	}
@@ -99,33 +184,48 @@ func (mediator *ServerMediator) FromMatchmaker(e event.Event) bool {
 		assert.NotNil(mediator.matchmaker, "matchmaker was nil")
 		assert.NotNil(mediator.serverData, "serverData was nil")
 
-		slog.Debug("players matched event", "id1", ePlayersMatched.Ids[0], "id2", ePlayersMatched.Ids[1])
+		requests := ePlayersMatched.Requests
+
+		slog.Debug("players matched event", "id1", requests[0].Id, "id2", requests[1].Id, "game", requests[0].Game)
 
-		ids := ePlayersMatched.Ids
 		conns := [2]*handlers.PlayerConnection{}
 		var confirm[2]bool
 
 		for i := range conns {
-			conn, err := mediator.serverData.GetConnection(ids[i])
-			
-			// TODO: funcition connection confirm
-			if err != nil || conn.GetConnection().SendPing() != nil {
-				confirm[i] = false
-				continue
-			} 
-
-			conns[i] = conn
-			confirm[i] = true
+			conns[i], confirm[i] = mediator.confirmConnection(requests[i].Id)
 		}
 
 		if confirm[0] && confirm[1] {
-			room := mediator.CreateRoom(conns)
+			request := requests[0]
+			room := mediator.CreateRoom(conns, request.Game, request.Params, request.TimeControl, request.BestOf)
 
 			mediator.serverData.AddRoom(room)
 		} else if confirm[0] {
-			mediator.matchmaker.Add(ids[0])
+			mediator.matchmaker.Add(requests[0])
 		} else if confirm[1] {
-			mediator.matchmaker.Add(ids[1])
+			mediator.matchmaker.Add(requests[1])
+		}
+
+	case event.EventTypeBotMatched:
+		eBotMatched, ok := e.(matchmaker.EventBotMatched)
+		assert.Assert(ok, "type assertion failed for event bot matched")
+		assert.NotNil(mediator.serverData, "serverData was nil")
+
+		request := eBotMatched.Request
+
+		slog.Debug("bot matched event", "id", request.Id, "game", request.Game)
+
+		conn, confirmed := mediator.confirmConnection(request.Id)
+		if !confirmed {
+			break
+		}
+
+		room := mediator.CreateBotRoom(conn, request.Game, request.Params, request.TimeControl, request.BestOf)
+
+		if room != nil {
+			mediator.serverData.AddRoom(room)
+		} else {
+			mediator.matchmaker.Add(request)
 		}
 	default:
 		return false
@@ -134,26 +234,571 @@ func (mediator *ServerMediator) FromMatchmaker(e event.Event) bool {
 	return true
 }
 
-func (mediator *ServerMediator) CreateRoom(pConnections [2]*handlers.PlayerConnection) *handlers.Room {
+// confirmConnection checks if the connection still exists and responds, and waits for an opponent.
+// Players who host a private room or have joined one are not matched.
+func (mediator *ServerMediator) confirmConnection(id uuid.UUID) (*handlers.PlayerConnection, bool) {
+	assert.NotNil(mediator.serverData, "serverData was nil")
+
+	conn, err := mediator.serverData.GetConnection(id)
+
+	if err != nil || conn.IsInRoom() || conn.GetConnection().SendPing() != nil {
+		return nil, false
+	}
+
+	if _, isHost := mediator.serverData.GetInviteOfHost(id); isHost {
+		return nil, false
+	}
+
+	return conn, true
+}
+
+func (mediator *ServerMediator) CreateRoom(pConnections [2]*handlers.PlayerConnection, game string, params gameRules.Params, control handlers.TimeControl, bestOf int) *handlers.Room {
 	assert.NotNil(mediator.handler, "server handler was nil")
+	assert.NotNil(mediator.games, "game registry was nil")
+
+	rules, err := mediator.games.Create(game, params)
+	assert.NoError(err, "cannot create game rules", "game", game)
+
+	config := mediator.createRoomConfig(game, params, control, bestOf)
+	ranked := isRanked(pConnections)
+
+	if ranked {
+		config.Ratings = mediator.ratings
+	}
+
+	uuid := mediator.GenerateUUID()
+	room := handlers.CreateRoom(mediator.handler.GetSync(), pConnections, uuid, rules, config)
+
+	slog.Info("created room", "uuid", uuid.String(), "game", game, "ranked", ranked)
+
+	assert.NotNil(room, "room was nil")
+	return room
+}
+
+// isRanked checks that both players have proven their names, so nobody else changes their ratings.
+// Games against bots are never ranked.
+func isRanked(pConnections [2]*handlers.PlayerConnection) bool {
+	if !pConnections[0].IsVerified() || !pConnections[1].IsVerified() {
+		return false
+	}
+
+	return pConnections[0].GetName() != pConnections[1].GetName()
+}
+
+// CreateBotRoom returns nil if the game cannot be played by bots.
+func (mediator *ServerMediator) CreateBotRoom(pConn *handlers.PlayerConnection, game string, params gameRules.Params, control handlers.TimeControl, bestOf int) *handlers.Room {
+	assert.NotNil(mediator.handler, "server handler was nil")
+	assert.NotNil(mediator.games, "game registry was nil")
+	assert.NotNil(pConn, "player connection was nil")
+
+	rules, err := mediator.games.Create(game, params)
+	assert.NoError(err, "cannot create game rules", "game", game)
+
+	searchable, ok := rules.(gameRules.Searchable)
+	if !ok {
+		slog.Warn("game cannot be played by bots", "game", game)
+		return nil
+	}
+
+	difficulty := pConn.GetBotDifficulty()
+	createEngine := func() engine.Engine {
+		return engine.CreateEngine(difficulty, time.Now().UnixNano())
+	}
 
 	uuid := mediator.GenerateUUID()
-	room := handlers.CreateRoom(mediator.handler.GetSync(), pConnections, uuid)
+	room := handlers.CreateBotRoom(mediator.handler.GetSync(), pConn, uuid, searchable, mediator.createRoomConfig(game, params, control, bestOf), createEngine)
 
-	slog.Info("created room", "uuid", uuid.String())
+	slog.Info("created bot room", "uuid", uuid.String(), "game", game, "difficulty", difficulty)
 
 	assert.NotNil(room, "room was nil")
 	return room
 }
 
+func (mediator *ServerMediator) createRoomConfig(game string, params gameRules.Params, control handlers.TimeControl, bestOf int) handlers.RoomConfig {
+	config := handlers.CreateRoomConfig(params)
+	config.ReconnectGrace = mediator.reconnectGrace
+	config.TimeControl = control
+	config.BestOf = bestOf
+	config.CreateRules = func() (gameRules.GameRules, error) {
+		return mediator.games.Create(game, params)
+	}
+
+	return config
+}
+
+// SetReconnectGrace sets for how long new rooms hold seats of disconnected players.
+func (mediator *ServerMediator) SetReconnectGrace(grace time.Duration) {
+	assert.Assert(grace >= 0, "reconnect grace cannot be negative", "grace", grace)
+
+	mediator.reconnectGrace = grace
+}
+
+// SetInviteTimeout sets for how long new invite codes of private rooms are valid.
+func (mediator *ServerMediator) SetInviteTimeout(timeout time.Duration) {
+	assert.Assert(timeout > 0, "invite timeout must be positive", "timeout", timeout)
+
+	mediator.inviteTimeout = timeout
+}
+
+// SetTimeControl sets time control of new rooms of the game.
+func (mediator *ServerMediator) SetTimeControl(game string, control handlers.TimeControl) {
+	assert.NotNil(mediator.games, "game registry was nil")
+	assert.Assert(mediator.games.Has(game), "game is not registered", "game", game)
+	assert.Assert(control.Total >= 0 && control.Increment >= 0 && control.MoveTime >= 0, "time control cannot be negative")
+
+	mediator.timeControls[game] = control
+}
+
+// SetBestOf sets number of games in series of new rooms of the game, when players do not choose it.
+func (mediator *ServerMediator) SetBestOf(game string, games int) {
+	assert.NotNil(mediator.games, "game registry was nil")
+	assert.Assert(mediator.games.Has(game), "game is not registered", "game", game)
+	assert.Assert(games >= 1, "series must have at least one game", "games", games)
+
+	mediator.bestOf[game] = games
+}
+
+// CreatePrivateRoom takes the player out of the public queue and sends it an invite code.
+// The player gets the same code again, until it expires.
+func (mediator *ServerMediator) CreatePrivateRoom(connId uuid.UUID, choice handlers.GameChoice) {
+	invite, ok := mediator.createInvite(connId, false, choice)
+	if !ok {
+		return
+	}
+
+	err := mediator.SendMessage(connId, serverMsg.MakeMessage(serverMsg.TPrivateRoomCreated, serverMsg.PrivateRoomCreated{
+		Code: invite.Code,
+		ExpiresIn: int(time.Until(invite.ExpiresAt).Seconds()),
+	}))
+
+	if err != nil {
+		slog.Warn("cannot send invite code", "uuid", connId, "err", err)
+	}
+}
+
+// OpenRoom lists a room of the player in the lobby, otherwise it is the same as CreatePrivateRoom.
+func (mediator *ServerMediator) OpenRoom(connId uuid.UUID, choice handlers.GameChoice) {
+	invite, ok := mediator.createInvite(connId, true, choice)
+	if !ok {
+		return
+	}
+
+	err := mediator.SendMessage(connId, serverMsg.MakeMessage(serverMsg.TRoomOpened, serverMsg.RoomOpened{
+		ID: invite.ID.String(),
+		Code: invite.Code,
+		ExpiresIn: int(time.Until(invite.ExpiresAt).Seconds()),
+	}))
+
+	if err != nil {
+		slog.Warn("cannot send open room", "uuid", connId, "err", err)
+	}
+}
+
+// createInvite returns the invite the player already hosts, or a new one.
+// It is false when the player cannot host, the player was told why.
+func (mediator *ServerMediator) createInvite(connId uuid.UUID, public bool, choice handlers.GameChoice) (serverData.Invite, bool) {
+	assert.NotNil(mediator.serverData, "serverData was nil")
+	assert.NotNil(mediator.matchmaker, "matchmaker was nil")
+	assert.NotNil(mediator.games, "game registry was nil")
+
+	pConn, err := mediator.serverData.GetConnection(connId)
+	if err != nil {
+		return serverData.Invite{}, false
+	}
+
+	// Matchmaker could have seated the player after it has sent the request.
+	if pConn.IsInRoom() {
+		mediator.sendNotAllowed(connId, "cannot do this during a game")
+		return serverData.Invite{}, false
+	}
+
+	invite, ok := mediator.serverData.GetInviteOfHost(connId)
+
+	if ok {
+		if invite.Public != public {
+			mediator.sendNotAllowed(connId, "you already host a room")
+			return serverData.Invite{}, false
+		}
+
+		return invite, true
+	}
+
+	request, err := mediator.createRequest(connId, choice)
+	if err != nil {
+		mediator.sendNotAllowed(connId, err.Error())
+		return serverData.Invite{}, false
+	}
+
+	now := time.Now()
+	invite = serverData.Invite{
+		ID: mediator.GenerateUUID(),
+		Code: mediator.generateInviteCode(),
+		Host: connId,
+		HostName: pConn.GetName(),
+		Game: request.Game,
+		Params: request.Params,
+		TimeControl: request.TimeControl,
+		BestOf: request.BestOf,
+		Public: public,
+		CreatedAt: now,
+		ExpiresAt: now.Add(mediator.inviteTimeout),
+	}
+
+	mediator.serverData.AddInvite(invite)
+	mediator.matchmaker.Remove(connId)
+
+	slog.Info("created invite", "code", invite.Code, "host", connId, "game", invite.Game, "public", public)
+	return invite, true
+}
+
+// JoinPrivateRoom seats the player with the host of the invite, neither of them goes through the matchmaker.
+func (mediator *ServerMediator) JoinPrivateRoom(connId uuid.UUID, code string) {
+	assert.NotNil(mediator.serverData, "serverData was nil")
+	assert.NotNil(mediator.matchmaker, "matchmaker was nil")
+
+	pConn, err := mediator.serverData.GetConnection(connId)
+	if err != nil {
+		return
+	}
+
+	if pConn.IsInRoom() {
+		mediator.sendNotAllowed(connId, "cannot do this during a game")
+		return
+	}
+
+	code = serverData.NormalizeInviteCode(code)
+
+	if invite, isHost := mediator.serverData.GetInviteOfHost(connId); isHost && invite.Code == code {
+		mediator.sendNotAllowed(connId, "cannot join your own private room")
+		return
+	}
+
+	invite, err := mediator.serverData.TakeInvite(code)
+	if err != nil {
+		mediator.sendNotAllowed(connId, "invite code is not valid or has expired")
+		return
+	}
+
+	mediator.seatWithHost(pConn, invite)
+}
+
+// JoinRoom seats the player in an open room of the lobby, or adds it as a spectator to a room in play.
+func (mediator *ServerMediator) JoinRoom(connId uuid.UUID, roomId string) {
+	assert.NotNil(mediator.serverData, "serverData was nil")
+	assert.NotNil(mediator.matchmaker, "matchmaker was nil")
+
+	pConn, err := mediator.serverData.GetConnection(connId)
+	if err != nil {
+		return
+	}
+
+	if pConn.IsInRoom() {
+		mediator.sendNotAllowed(connId, "cannot do this during a game")
+		return
+	}
+
+	id, err := uuid.Parse(roomId)
+	if err != nil {
+		mediator.sendNotAllowed(connId, "room id is not valid")
+		return
+	}
+
+	if invite, isHost := mediator.serverData.GetInviteOfHost(connId); isHost && invite.ID == id {
+		mediator.sendNotAllowed(connId, "cannot join your own room")
+		return
+	}
+
+	invite, err := mediator.serverData.TakePublicInvite(id)
+	if err == nil {
+		mediator.seatWithHost(pConn, invite)
+		return
+	}
+
+	room, err := mediator.serverData.GetRoom(id)
+	if err != nil {
+		mediator.sendNotAllowed(connId, "room does not exist")
+		return
+	}
+
+	// Spectator does not wait for an opponent anymore.
+	mediator.serverData.RemoveInviteOfHost(connId)
+	mediator.matchmaker.Remove(connId)
+	mediator.lobby.Unsubscribe(connId)
+
+	room.AddSpectator(pConn)
+
+	slog.Info("spectator joined from lobby", "uuid", connId, "room", id)
+}
+
+// seatWithHost starts the game of the invite, neither of the players goes through the matchmaker.
+func (mediator *ServerMediator) seatWithHost(pConn *handlers.PlayerConnection, invite serverData.Invite) {
+	assert.NotNil(pConn, "player connection was nil")
+
+	connId := pConn.GetUUID()
+
+	host, confirmed := mediator.confirmConnection(invite.Host)
+	if !confirmed {
+		mediator.sendNotAllowed(connId, "host of the room has left")
+		return
+	}
+
+	// Player who joins could host its own room, or wait in the public queue.
+	mediator.serverData.RemoveInviteOfHost(connId)
+	mediator.matchmaker.Remove(connId)
+	mediator.lobby.Unsubscribe(connId)
+	mediator.lobby.Unsubscribe(invite.Host)
+
+	room := mediator.CreateRoom([2]*handlers.PlayerConnection{host, pConn}, invite.Game, invite.Params, invite.TimeControl, invite.BestOf)
+	mediator.serverData.AddRoom(room)
+
+	slog.Info("player joined invite", "code", invite.Code, "room", room.GetUUID())
+}
+
+// SendLobby sends the room list to the player.
+func (mediator *ServerMediator) SendLobby(connId uuid.UUID) {
+	err := mediator.SendMessage(connId, serverMsg.MakeMessage(serverMsg.TLobby, serverMsg.Lobby{
+		Rooms: mediator.getLobbyRooms(),
+	}))
+
+	if err != nil {
+		slog.Warn("cannot send lobby", "uuid", connId, "err", err)
+	}
+}
+
+// SubscribeLobby sends the room list to the player now and every time it changes.
+func (mediator *ServerMediator) SubscribeLobby(connId uuid.UUID, subscribe bool) {
+	assert.NotNil(mediator.lobby, "lobby was nil")
+
+	if !subscribe {
+		mediator.lobby.Unsubscribe(connId)
+		return
+	}
+
+	mediator.lobby.Subscribe(connId)
+	mediator.lobby.Update(mediator.getLobbyRooms())
+	mediator.SendLobby(connId)
+}
+
+// updateLobby sends the room list to subscribers when it has changed.
+// Players who have been seated in a room are not subscribed anymore.
+func (mediator *ServerMediator) updateLobby() {
+	assert.NotNil(mediator.lobby, "lobby was nil")
+
+	if !mediator.lobby.HasSubscribers() {
+		return
+	}
+
+	for _, id := range mediator.lobby.GetSubscribers() {
+		pConn, err := mediator.serverData.GetConnection(id)
+
+		if err != nil || pConn.IsInRoom() {
+			mediator.lobby.Unsubscribe(id)
+		}
+	}
+
+	rooms := mediator.getLobbyRooms()
+
+	if !mediator.lobby.Update(rooms) {
+		return
+	}
+
+	msg := serverMsg.MakeMessage(serverMsg.TLobby, serverMsg.Lobby{
+		Rooms: rooms,
+	})
+
+	for _, id := range mediator.lobby.GetSubscribers() {
+		err := mediator.SendMessage(id, msg)
+
+		if err != nil {
+			slog.Warn("cannot send lobby", "uuid", id, "err", err)
+		}
+	}
+}
+
+func (mediator *ServerMediator) getLobbyRooms() []serverMsg.LobbyRoom {
+	assert.NotNil(mediator.serverData, "serverData was nil")
+
+	return lobby.CreateRooms(mediator.serverData.GetPublicInvites(), mediator.serverData.GetRoomInfos())
+}
+
+// expireInvites returns hosts of expired invites to the public queue of the invite game.
+func (mediator *ServerMediator) expireInvites() {
+	assert.NotNil(mediator.serverData, "serverData was nil")
+	assert.NotNil(mediator.matchmaker, "matchmaker was nil")
+
+	for _, invite := range mediator.serverData.RemoveExpiredInvites(time.Now()) {
+		slog.Info("invite expired", "code", invite.Code, "host", invite.Host)
+
+		err := mediator.SendMessage(invite.Host, serverMsg.MakeMessage(serverMsg.TInviteExpired, serverMsg.InviteExpired{
+			Code: invite.Code,
+		}))
+
+		if err != nil {
+			continue
+		}
+
+		mediator.matchmaker.Add(matchmaker.Request{
+			Id: invite.Host,
+			Game: invite.Game,
+			Params: invite.Params,
+			TimeControl: invite.TimeControl,
+			BestOf: invite.BestOf,
+			Rating: mediator.getRating(invite.Host, invite.Game),
+		})
+	}
+}
+
+// Enqueue puts the player in the public queue of the game, instead of the queue or invite it had.
+func (mediator *ServerMediator) Enqueue(connId uuid.UUID, choice handlers.GameChoice) {
+	assert.NotNil(mediator.serverData, "serverData was nil")
+	assert.NotNil(mediator.matchmaker, "matchmaker was nil")
+
+	pConn, err := mediator.serverData.GetConnection(connId)
+	if err != nil {
+		return
+	}
+
+	if pConn.IsInRoom() {
+		mediator.sendNotAllowed(connId, "cannot do this during a game")
+		return
+	}
+
+	request, err := mediator.createRequest(connId, choice)
+	if err != nil {
+		mediator.sendNotAllowed(connId, err.Error())
+		return
+	}
+
+	mediator.serverData.RemoveInviteOfHost(connId)
+	mediator.matchmaker.Add(request)
+
+	slog.Info("player enqueued", "uuid", connId, "game", request.Game, "params", request.Params)
+
+	err = mediator.SendMessage(connId, serverMsg.MakeMessage(serverMsg.TQueued, serverMsg.Queued{
+		Game: request.Game,
+		Params: request.Params,
+	}))
+
+	if err != nil {
+		slog.Warn("cannot send queued message", "uuid", connId, "err", err)
+	}
+}
+
+// createRequest checks that the game can be created with the params, and fills in defaults of the game and the server.
+func (mediator *ServerMediator) createRequest(connId uuid.UUID, choice handlers.GameChoice) (matchmaker.Request, error) {
+	assert.NotNil(mediator.games, "game registry was nil")
+
+	game := choice.Game
+	if game == "" {
+		game = mediator.games.GetDefault()
+	}
+
+	if !mediator.games.Has(game) {
+		return matchmaker.Request{}, errors.New("game is not supported")
+	}
+
+	rules, err := mediator.games.Create(game, choice.Params)
+	if err != nil {
+		return matchmaker.Request{}, err
+	}
+
+	// Params are resolved, so players who leave out default params are paired with those who set them.
+	params := gameRules.Params{}
+	if parametrized, ok := rules.(gameRules.Parametrized); ok {
+		params = parametrized.GetParams()
+	}
+
+	control := choice.TimeControl
+
+	if control.Total < 0 || control.Increment < 0 || control.MoveTime < 0 {
+		return matchmaker.Request{}, errors.New("time control cannot be negative")
+	}
+
+	if !control.IsLimited() {
+		control = mediator.timeControls[game]
+	}
+
+	if choice.BestOf < 0 || choice.BestOf > handlers.MaxBestOf {
+		return matchmaker.Request{}, fmt.Errorf("series can have at most %d games", handlers.MaxBestOf)
+	}
+
+	bestOf := choice.BestOf
+	if bestOf == 0 {
+		bestOf = mediator.bestOf[game]
+	}
+
+	return matchmaker.Request{
+		Id: connId,
+		Game: game,
+		Params: params,
+		TimeControl: control,
+		BestOf: max(bestOf, 1),
+		Rating: mediator.getRating(connId, game),
+	}, nil
+}
+
+// getRating returns rating of the player in the game, players are paired with opponents of similar rating.
+// Players without a proven name have the default rating.
+func (mediator *ServerMediator) getRating(connId uuid.UUID, game string) float64 {
+	assert.NotNil(mediator.serverData, "server data was nil")
+	assert.NotNil(mediator.ratings, "ratings was nil")
+
+	pConn, err := mediator.serverData.GetConnection(connId)
+	if err != nil || !pConn.IsVerified() {
+		return matchmaker.DefaultRating
+	}
+
+	return mediator.ratings.Get(game, pConn.GetName()).Rating.Value
+}
+
+// SetRatingSystem sets how ranked games are rated from now on, ratings of the players are kept.
+func (mediator *ServerMediator) SetRatingSystem(system rating.System) {
+	assert.NotNil(mediator.ratings, "ratings was nil")
+
+	mediator.ratings.SetSystem(system)
+}
+
+// defaultRequest is the queue of players who connect without choosing a game.
+func (mediator *ServerMediator) defaultRequest(connId uuid.UUID) matchmaker.Request {
+	request, err := mediator.createRequest(connId, handlers.GameChoice{})
+	assert.NoError(err, "default game cannot be created")
+
+	return request
+}
+
+func (mediator *ServerMediator) generateInviteCode() string {
+	code := serverData.CreateInviteCode()
+
+	for mediator.serverData.HasInvite(code) {
+		code = serverData.CreateInviteCode()
+	}
+
+	return code
+}
+
+func (mediator *ServerMediator) sendNotAllowed(connId uuid.UUID, reason string) {
+	err := mediator.SendMessage(connId, serverMsg.MakeMessage(serverMsg.TNotAllowedErr, &serverMsg.NotAllowedErrMessage{
+		Reason: reason,
+	}))
+
+	if err != nil {
+		slog.Warn("cannot send message to connection", "uuid", connId, "err", err)
+	}
+}
+
 func (mediator *ServerMediator) RemoveRoom(uuid uuid.UUID) {
 	assert.NotNil(mediator.serverData, "serverData was nil")
 
-	_, err := mediator.serverData.GetRoom(uuid)
+	assert.NotNil(mediator.logs, "log store was nil")
+
+	room, err := mediator.serverData.GetRoom(uuid)
 	assert.NoError(err, "room does not exist")
 
 	slog.Info("removing room", "uuid", uuid)
 	mediator.serverData.RemoveRoom(uuid)
+
+	// Room is not updated anymore, so its log can be read by replay viewers.
+	mediator.logs.Add(uuid, room.GetLog())
 }
 
 func (mediator *ServerMediator) SendMessage(connId uuid.UUID, msg message.Message) error {
@@ -187,7 +832,10 @@ func (mediator *ServerMediator) GenerateUUID() uuid.UUID {
 	return uuid
 }
 
-func (mediator *ServerMediator) AddConnection(conn *connection.Connection) {
+// AddConnection adds a player, verified is true when the player has proven the name with its token.
+// The player is put in the queue of the default game, unless enqueue is false.
+// Such player chooses the game with enqueue message, or plays in a private room.
+func (mediator *ServerMediator) AddConnection(conn *connection.Connection, botDifficulty engine.Difficulty, name string, verified bool, enqueue bool) {
 	assert.NotNil(mediator.serverData, "server data was nil")
 	assert.NotNil(mediator.handler, "server handler was nil")
 	assert.NotNil(mediator.matchmaker, "server handler was nil")
@@ -195,15 +843,101 @@ func (mediator *ServerMediator) AddConnection(conn *connection.Connection) {
 
 	id := mediator.GenerateUUID()
 	pConn := handlers.CreatePlayerConnection(mediator.handler.GetSync(), id, conn)
+	pConn.SetBotDifficulty(botDifficulty)
+	pConn.SetName(name)
+	pConn.SetVerified(verified)
 
 	mediator.serverData.AddPlayerConnection(id, pConn)
 
 	pConn.StartLoop()
-	mediator.matchmaker.Add(id)
+
+	if enqueue {
+		mediator.matchmaker.Add(mediator.defaultRequest(id))
+	}
 
 	slog.Info("connected to", "ip", conn.GetRemoteIP(), "uuid", id.String())
 }
 
+// AddSpectator connects a spectator to the room, it is not added to the matchmaker.
+func (mediator *ServerMediator) AddSpectator(conn *connection.Connection, roomUUID uuid.UUID) error {
+	assert.NotNil(mediator.serverData, "server data was nil")
+	assert.NotNil(mediator.handler, "server handler was nil")
+	assert.NotNil(conn, "connection was nil")
+
+	room, err := mediator.serverData.GetRoom(roomUUID)
+	if err != nil {
+		return err
+	}
+
+	id := mediator.GenerateUUID()
+	pConn := handlers.CreatePlayerConnection(mediator.handler.GetSync(), id, conn)
+
+	mediator.serverData.AddPlayerConnection(id, pConn)
+
+	pConn.StartLoop()
+	room.AddSpectator(pConn)
+
+	slog.Info("spectator connected to", "ip", conn.GetRemoteIP(), "uuid", id.String(), "room", roomUUID.String())
+	return nil
+}
+
+// ResumeConnection binds the connection to the seat with the resume token.
+func (mediator *ServerMediator) ResumeConnection(conn *connection.Connection, token string, name string) error {
+	assert.NotNil(mediator.serverData, "server data was nil")
+	assert.NotNil(mediator.handler, "server handler was nil")
+	assert.NotNil(conn, "connection was nil")
+
+	room, ok := mediator.findRoomByResumeToken(token)
+	if !ok {
+		return errors.New("resume token is not valid")
+	}
+
+	id := mediator.GenerateUUID()
+	pConn := handlers.CreatePlayerConnection(mediator.handler.GetSync(), id, conn)
+	pConn.SetName(name)
+
+	mediator.serverData.AddPlayerConnection(id, pConn)
+
+	pConn.StartLoop()
+	room.Resume(pConn, token)
+
+	slog.Info("player reconnecting to", "ip", conn.GetRemoteIP(), "uuid", id.String(), "room", room.GetUUID().String())
+	return nil
+}
+
+func (mediator *ServerMediator) HasResumeToken(token string) bool {
+	_, ok := mediator.findRoomByResumeToken(token)
+	return ok
+}
+
+func (mediator *ServerMediator) findRoomByResumeToken(token string) (*handlers.Room, bool) {
+	assert.NotNil(mediator.serverData, "server data was nil")
+
+	var found *handlers.Room
+
+	mediator.serverData.ForEachRoom(func(room *handlers.Room) {
+		if room.HasResumeToken(token) {
+			found = room
+		}
+	})
+
+	return found, found != nil
+}
+
+func (mediator *ServerMediator) HasRoom(roomUUID uuid.UUID) bool {
+	assert.NotNil(mediator.serverData, "server data was nil")
+
+	_, err := mediator.serverData.GetRoom(roomUUID)
+	return err == nil
+}
+
+// GetRoomInfos can be called from any goroutine, rooms are not updated while they are listed.
+func (mediator *ServerMediator) GetRoomInfos() []handlers.RoomInfo {
+	assert.NotNil(mediator.serverData, "server data was nil")
+
+	return mediator.serverData.GetRoomInfos()
+}
+
 func (mediator *ServerMediator) DeleteConnection(id uuid.UUID) {
 	assert.NotNil(mediator.serverData, "server data was nil")
 
@@ -214,7 +948,9 @@ func (mediator *ServerMediator) DeleteConnection(id uuid.UUID) {
 
 	conn.EndLoop()
 
+	mediator.serverData.RemoveInviteOfHost(id)
 	mediator.serverData.RemoveConnection(id)
+	mediator.lobby.Unsubscribe(id)
 }
 
 func (mediator *ServerMediator) AddConnectionToMatchmaker(uuid uuid.UUID) {
@@ -222,13 +958,15 @@ func (mediator *ServerMediator) AddConnectionToMatchmaker(uuid uuid.UUID) {
 	assert.NoError(err, "connection does not exist")
 
 	slog.Debug("adding player to matchmaker", "uuid", uuid.String())
-	mediator.matchmaker.Add(uuid)
+	mediator.matchmaker.Add(mediator.defaultRequest(uuid))
 }
 
 func (mediator *ServerMediator) Update() {
 	assert.NotNil(mediator.handler, "server handler was nil")
 
 	mediator.updateAllRooms()
+	mediator.expireInvites()
+	mediator.updateLobby()
 	mediator.handler.GetSync().SyncTransferAll()
 }
 
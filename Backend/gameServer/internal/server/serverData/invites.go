@@ -0,0 +1,169 @@
+package serverData
+
+import (
+	"GridPlay/assert"
+	"GridPlay/gameRules"
+	"GridPlay/gameServer/internal/handlers"
+	"crypto/rand"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Time after which an unused invite code expires.
+const DefaultInviteTimeout = 5 * time.Minute
+
+const inviteCodeLength = 6
+
+// Letters and digits which cannot be confused when read aloud or typed, e.g. no 'O' and '0'.
+// There are 32 of them, so every random byte maps to a character with the same probability.
+const inviteAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// Invite is a room waiting for the second player, who joins with the code.
+// Public invites are open rooms listed in the lobby, they can be joined by ID too.
+type Invite struct {
+	ID uuid.UUID
+	Code string
+	Host uuid.UUID
+	HostName string
+	Game string
+	Params gameRules.Params
+	TimeControl handlers.TimeControl
+	BestOf int
+	Public bool
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// CreateInviteCode returns a random code, e.g. "K7QX2M".
+func CreateInviteCode() string {
+	bytes := make([]byte, inviteCodeLength)
+
+	_, err := rand.Read(bytes)
+	assert.NoError(err, "cannot read random bytes")
+
+	code := make([]byte, inviteCodeLength)
+
+	for i, b := range bytes {
+		code[i] = inviteAlphabet[int(b) % len(inviteAlphabet)]
+	}
+
+	return string(code)
+}
+
+// NormalizeInviteCode makes codes typed by players comparable, e.g. " k7qx2m" is "K7QX2M".
+func NormalizeInviteCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (srvData *ServerData) AddInvite(invite Invite) {
+	srvData.mut.Lock()
+	defer srvData.mut.Unlock()
+
+	_, exists := srvData.invites[invite.Code]
+	assert.Assert(!exists, "invite code is already used", "code", invite.Code)
+
+	srvData.invites[invite.Code] = invite
+}
+
+func (srvData *ServerData) HasInvite(code string) bool {
+	srvData.mut.Lock()
+	defer srvData.mut.Unlock()
+
+	_, ok := srvData.invites[code]
+	return ok
+}
+
+// TakeInvite removes the invite, so only one player can join with the code.
+func (srvData *ServerData) TakeInvite(code string) (Invite, error) {
+	srvData.mut.Lock()
+	defer srvData.mut.Unlock()
+
+	invite, ok := srvData.invites[code]
+
+	if !ok {
+		return Invite{}, errors.New("invite does not exist")
+	}
+
+	delete(srvData.invites, code)
+	return invite, nil
+}
+
+// TakePublicInvite removes the open room with the id, see TakeInvite.
+func (srvData *ServerData) TakePublicInvite(id uuid.UUID) (Invite, error) {
+	srvData.mut.Lock()
+	defer srvData.mut.Unlock()
+
+	for code, invite := range srvData.invites {
+		if invite.Public && invite.ID == id {
+			delete(srvData.invites, code)
+			return invite, nil
+		}
+	}
+
+	return Invite{}, errors.New("open room does not exist")
+}
+
+// GetPublicInvites returns open rooms, oldest first.
+func (srvData *ServerData) GetPublicInvites() []Invite {
+	srvData.mut.Lock()
+	defer srvData.mut.Unlock()
+
+	invites := []Invite{}
+
+	for _, invite := range srvData.invites {
+		if invite.Public {
+			invites = append(invites, invite)
+		}
+	}
+
+	sort.Slice(invites, func(i, j int) bool {
+		return invites[i].CreatedAt.Before(invites[j].CreatedAt)
+	})
+
+	return invites
+}
+
+func (srvData *ServerData) GetInviteOfHost(host uuid.UUID) (Invite, bool) {
+	srvData.mut.Lock()
+	defer srvData.mut.Unlock()
+
+	for _, invite := range srvData.invites {
+		if invite.Host == host {
+			return invite, true
+		}
+	}
+
+	return Invite{}, false
+}
+
+func (srvData *ServerData) RemoveInviteOfHost(host uuid.UUID) {
+	srvData.mut.Lock()
+	defer srvData.mut.Unlock()
+
+	for code, invite := range srvData.invites {
+		if invite.Host == host {
+			delete(srvData.invites, code)
+		}
+	}
+}
+
+// RemoveExpiredInvites returns invites which have expired before now.
+func (srvData *ServerData) RemoveExpiredInvites(now time.Time) []Invite {
+	srvData.mut.Lock()
+	defer srvData.mut.Unlock()
+
+	expired := []Invite{}
+
+	for code, invite := range srvData.invites {
+		if !now.Before(invite.ExpiresAt) {
+			expired = append(expired, invite)
+			delete(srvData.invites, code)
+		}
+	}
+
+	return expired
+}
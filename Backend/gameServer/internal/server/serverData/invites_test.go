@@ -0,0 +1,55 @@
+package serverData
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInviteCode(t *testing.T) {
+	code := CreateInviteCode()
+
+	require.Len(t, code, inviteCodeLength)
+
+	for _, c := range code {
+		require.True(t, strings.ContainsRune(inviteAlphabet, c), "code has character %c", c)
+	}
+
+	require.Equal(t, code, NormalizeInviteCode(" " + strings.ToLower(code) + "\n"))
+}
+
+func TestTakeInvite(t *testing.T) {
+	srvData := CreateServerData()
+	host := uuid.New()
+
+	srvData.AddInvite(Invite{Code: "ABC234", Host: host, ExpiresAt: time.Now().Add(time.Minute)})
+
+	invite, ok := srvData.GetInviteOfHost(host)
+	require.True(t, ok)
+	require.Equal(t, "ABC234", invite.Code)
+
+	invite, err := srvData.TakeInvite("ABC234")
+	require.NoError(t, err)
+	require.Equal(t, host, invite.Host)
+
+	_, err = srvData.TakeInvite("ABC234")
+	require.Error(t, err)
+}
+
+func TestRemoveExpiredInvites(t *testing.T) {
+	srvData := CreateServerData()
+	now := time.Now()
+
+	srvData.AddInvite(Invite{Code: "AAAAAA", Host: uuid.New(), ExpiresAt: now})
+	srvData.AddInvite(Invite{Code: "BBBBBB", Host: uuid.New(), ExpiresAt: now.Add(time.Second)})
+
+	expired := srvData.RemoveExpiredInvites(now)
+
+	require.Len(t, expired, 1)
+	require.Equal(t, "AAAAAA", expired[0].Code)
+	require.False(t, srvData.HasInvite("AAAAAA"))
+	require.True(t, srvData.HasInvite("BBBBBB"))
+}
@@ -4,6 +4,7 @@ import (
 	"GridPlay/assert"
 	"GridPlay/gameServer/internal/handlers"
 	"errors"
+	"sort"
 	"sync"
 
 	"github.com/google/uuid"
@@ -12,6 +13,8 @@ import (
 type ServerData struct {
 	connections map[uuid.UUID]*handlers.PlayerConnection
 	rooms       map[uuid.UUID]*handlers.Room
+	// Private rooms waiting for the second player, by invite code.
+	invites map[string]Invite
 	mut sync.Mutex
 }
 
@@ -19,6 +22,7 @@ func CreateServerData() *ServerData {
 	 return &ServerData{
 		connections: make(map[uuid.UUID]*handlers.PlayerConnection),
 		rooms: make(map[uuid.UUID]*handlers.Room),
+		invites: make(map[string]Invite),
 	 }
 }
 
@@ -60,6 +64,24 @@ func (srvData *ServerData) ForEachRoom(f func (room *handlers.Room)) {
 	}
 }
 
+// GetRoomInfos returns info of all rooms, oldest first. Rooms must not be updated meanwhile.
+func (srvData *ServerData) GetRoomInfos() []handlers.RoomInfo {
+	srvData.mut.Lock()
+	defer srvData.mut.Unlock()
+
+	infos := make([]handlers.RoomInfo, 0, len(srvData.rooms))
+
+	for _, room := range srvData.rooms {
+		infos = append(infos, room.GetInfo())
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].CreatedAt.Before(infos[j].CreatedAt)
+	})
+
+	return infos
+}
+
 func (srvData *ServerData) AddPlayerConnection(uuid uuid.UUID, pConn *handlers.PlayerConnection) {
 	assert.NotNil(pConn, "player connection was nil")
 
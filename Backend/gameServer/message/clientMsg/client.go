@@ -2,23 +2,113 @@ package clientMsg
 
 import (
 	"GridPlay/assert"
+	"GridPlay/gameRules"
 	"GridPlay/gameServer/message"
+	"encoding/json"
 )
 
 type MsgType message.MsgType
 const (
 	TMove MsgType = iota
+	TTakeBackRequest
+	TTakeBackAnswer
+	TGameStateRequest
+	TResign
+	TOfferDraw
+	TAcceptDraw
+	TDeclineDraw
+	TRematchRequest
+	TRematchAnswer
+	TCreatePrivateRoom
+	TJoinPrivateRoom
+	TOpenRoom
+	TJoinRoom
+	TLobbyRequest
+	TLobbySubscribe
+	TEnqueue
 )
 
-type MoveMessage struct {
-	X int `json:"x"`
-	Y int `json:"y"`
+// MoveMessage data depends on game type and is decoded by game rules.
+type MoveMessage = json.RawMessage
+
+// TakeBackAnswer is opponent's reply to a take-back request.
+type TakeBackAnswer struct {
+	Accept bool `json:"accept"`
+}
+
+// RematchAnswer is opponent's reply to a rematch request.
+type RematchAnswer struct {
+	Accept bool `json:"accept"`
+}
+
+// GameChoice is the game of enqueue, open room and private room messages, data may be null.
+// Empty Game is the default game of the server, zero TimeControl and BestOf are the ones set on the server for the game.
+type GameChoice struct {
+	Game string `json:"game"`
+	Params gameRules.Params `json:"params"`
+	TimeControl TimeControl `json:"timeControl"`
+	// BestOf is the number of games in a series, see handlers.RoomConfig.
+	BestOf int `json:"bestOf"`
+}
+
+// TimeControl is in seconds, see handlers.TimeControl.
+type TimeControl struct {
+	Total int `json:"total"`
+	Increment int `json:"increment"`
+	MoveTime int `json:"moveTime"`
+}
+
+// JoinPrivateRoom Code is the invite code received by the host.
+type JoinPrivateRoom struct {
+	Code string `json:"code"`
+}
+
+// JoinRoom ID is a room from the lobby.
+type JoinRoom struct {
+	ID string `json:"id"`
+}
+
+// LobbySubscribe starts or stops live lobby updates.
+type LobbySubscribe struct {
+	Subscribe bool `json:"subscribe"`
 }
 
 func (msgT MsgType) String() string { 
 	switch msgT {
 	case TMove:
 		return "move"
+	case TTakeBackRequest:
+		return "take_back_request"
+	case TTakeBackAnswer:
+		return "take_back_answer"
+	case TGameStateRequest:
+		return "game_state_request"
+	case TResign:
+		return "resign"
+	case TOfferDraw:
+		return "offer_draw"
+	case TAcceptDraw:
+		return "accept_draw"
+	case TDeclineDraw:
+		return "decline_draw"
+	case TRematchRequest:
+		return "rematch_request"
+	case TRematchAnswer:
+		return "rematch_answer"
+	case TCreatePrivateRoom:
+		return "create_private_room"
+	case TJoinPrivateRoom:
+		return "join_private_room"
+	case TOpenRoom:
+		return "open_room"
+	case TJoinRoom:
+		return "join_room"
+	case TLobbyRequest:
+		return "lobby_request"
+	case TLobbySubscribe:
+		return "lobby_subscribe"
+	case TEnqueue:
+		return "enqueue"
 	default:
 		assert.Never("unknown type of client message", "client message", msgT)
 		return "unknown"
@@ -2,8 +2,10 @@ package serverMsg
 
 import (
 	"GridPlay/assert"
+	"GridPlay/gameRules"
 	"GridPlay/gameServer/message"
 	"fmt"
+	"time"
 )
 
 type MsgType message.MsgType
@@ -13,26 +15,187 @@ const (
 	TOpponentMove
 	TWinEvent
 	TNotAllowedErr
+	TTakeBackRequest
+	TTakeBackAns
+	TGameState
+	TReplayEntry
+	TSpectatorMove
+	TGameEnd
+	TOpponentConnection
+	TDrawOffer
+	TDrawDeclined
+	TRematchRequest
+	TRematchDeclined
+	TSeriesEnd
+	TPrivateRoomCreated
+	TInviteExpired
+	TRoomOpened
+	TLobby
+	TQueued
 )
 
 type MatchStarted struct {
+	Game string `json:"game"`
 	Char rune `json:"char"`
 	OpponentChar rune `json:"opponentChar"`
+	// ResumeToken lets the player reconnect to this seat, with /ws?resume=<token>.
+	ResumeToken string `json:"resumeToken"`
+	Series Series `json:"series"`
 }
 
+// MoveRes.Move is the move as resolved by the server,
+// e.g. position where connect four piece has fallen.
 type MoveRes struct {
 	Approved        bool   `json:"approved"`
 	Reason string `json:"reason"`
+	Move any `json:"move,omitempty"`
+	Clock *Clock `json:"clock,omitempty"`
 }
 
-type MoveMessage struct {
-	X int `json:"x"`
-	Y int `json:"y"`
+// Clock has remaining time of players in milliseconds, time of the Running player runs.
+// It is sent only in games with time control.
+type Clock struct {
+	Remaining [2]int64 `json:"remaining"`
+	Running int `json:"running"`
 }
 
+// MoveMessage is a game specific result of opponent move.
+type MoveMessage any
+
 type WinMessage struct {
 	Status string `json:"status"`
 	Cause string `json:"cause"`
+	// Series includes the game that has just ended.
+	Series Series `json:"series"`
+	// Rating is nil when the game was not ranked.
+	Rating *RatingChange `json:"rating,omitempty"`
+}
+
+// RatingChange of the player after a ranked game, rounded to whole points.
+type RatingChange struct {
+	Before float64 `json:"before"`
+	After float64 `json:"after"`
+	Deviation float64 `json:"deviation"`
+}
+
+// Series is the score of games played in the room, a win is 1 point and a draw half.
+type Series struct {
+	// BestOf is 1 for rooms without series.
+	BestOf int `json:"bestOf"`
+	Games int `json:"games"`
+	Score float64 `json:"score"`
+	OpponentScore float64 `json:"opponentScore"`
+}
+
+// Causes of the game end in WinMessage, empty when the game ended on the board.
+const (
+	CauseDisconnect = "disconnect"
+	CauseTimeout = "timeout"
+	CauseResignation = "resignation"
+	CauseAgreement = "agreement"
+)
+
+// TakeBackRequest asks the opponent to take back Moves last moves.
+type TakeBackRequest struct {
+	Moves int `json:"moves"`
+}
+
+// TakeBackAns is sent to the player who asked for a take-back.
+type TakeBackAns struct {
+	Accepted bool `json:"accepted"`
+	Reason string `json:"reason"`
+}
+
+// GameState carries the complete game, the client replaces everything it shows with it.
+type GameState struct {
+	gameRules.GameState
+	// Seat of the receiver, -1 for spectators.
+	Seat int `json:"seat"`
+	Clock *Clock `json:"clock,omitempty"`
+}
+
+// SpectatorMove is a move of the player on the seat, as resolved by the server.
+type SpectatorMove struct {
+	Seat int `json:"seat"`
+	Move any `json:"move"`
+}
+
+// GameEnd tells spectators the result, "1-0", "0-1" or "1/2-1/2", and why the game ended.
+type GameEnd struct {
+	Result string `json:"result"`
+	Termination string `json:"termination"`
+}
+
+// OpponentConnection tells the player that the opponent lost or regained connection.
+// Seat of a disconnected opponent is held for Grace seconds.
+type OpponentConnection struct {
+	Connected bool `json:"connected"`
+	Grace int `json:"grace"`
+}
+
+// DrawOffer is sent to the opponent of the player who offers a draw.
+type DrawOffer struct {}
+
+// DrawDeclined tells the player that its draw offer was declined or could not be made.
+type DrawDeclined struct {
+	Reason string `json:"reason"`
+}
+
+// SeriesEnd Status is "win", "lose" or "draw", the room is closed after it.
+type SeriesEnd struct {
+	Status string `json:"status"`
+	Series Series `json:"series"`
+}
+
+// RematchRequest is sent to the opponent of the player who asks for a rematch.
+type RematchRequest struct {}
+
+// RematchDeclined tells the player that its rematch request was declined or could not be made.
+type RematchDeclined struct {
+	Reason string `json:"reason"`
+}
+
+// PrivateRoomCreated has the invite code for the opponent, it expires in ExpiresIn seconds.
+type PrivateRoomCreated struct {
+	Code string `json:"code"`
+	ExpiresIn int `json:"expiresIn"`
+}
+
+// InviteExpired tells the host that nobody joined, the host is back in the public queue.
+type InviteExpired struct {
+	Code string `json:"code"`
+}
+
+// RoomOpened tells the host id of its room in the lobby, the code works as for private rooms.
+type RoomOpened struct {
+	ID string `json:"id"`
+	Code string `json:"code"`
+	ExpiresIn int `json:"expiresIn"`
+}
+
+// Lobby lists open rooms, then rooms where the game is played, both oldest first.
+type Lobby struct {
+	Rooms []LobbyRoom `json:"rooms"`
+}
+
+// LobbyRoom is joined as a player when it is Open, otherwise as a spectator.
+// Host is the name of the player who opened it, or of the first player in rooms without one.
+type LobbyRoom struct {
+	ID string `json:"id"`
+	Game string `json:"game"`
+	Params gameRules.Params `json:"params"`
+	Host string `json:"host"`
+	Open bool `json:"open"`
+	Spectators int `json:"spectators"`
+	// BestOf is 1 for rooms without series.
+	BestOf int `json:"bestOf"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Queued confirms that the player waits for an opponent in the game.
+type Queued struct {
+	Game string `json:"game"`
+	Params gameRules.Params `json:"params"`
 }
 
 type NotAllowedErrMessage struct {
@@ -51,6 +214,40 @@ func (msgT MsgType) String() string {
 		return "win_event"
 	case TNotAllowedErr:
 		return "not_allowed_error"
+	case TTakeBackRequest:
+		return "take_back_request"
+	case TTakeBackAns:
+		return "take_back_answer"
+	case TGameState:
+		return "game_state"
+	case TReplayEntry:
+		return "replay_entry"
+	case TSpectatorMove:
+		return "spectator_move"
+	case TGameEnd:
+		return "game_end"
+	case TOpponentConnection:
+		return "opponent_connection"
+	case TDrawOffer:
+		return "draw_offer"
+	case TDrawDeclined:
+		return "draw_declined"
+	case TRematchRequest:
+		return "rematch_request"
+	case TRematchDeclined:
+		return "rematch_declined"
+	case TSeriesEnd:
+		return "series_end"
+	case TPrivateRoomCreated:
+		return "private_room_created"
+	case TInviteExpired:
+		return "invite_expired"
+	case TRoomOpened:
+		return "room_opened"
+	case TLobby:
+		return "lobby"
+	case TQueued:
+		return "queued"
 	default:
 		assert.Never("unknown type of server message", "server message", msgT)
 		return "unknown"
@@ -64,5 +261,5 @@ func MakeMessage[T any](msgType MsgType, msgData T) message.Message {
 }
 
 func (msg MatchStarted) String() string {
-	return fmt.Sprintf("Char: %s OpponentChar: %s", string(msg.Char), string(msg.OpponentChar))
+	return fmt.Sprintf("Game: %s Char: %s OpponentChar: %s", msg.Game, string(msg.Char), string(msg.OpponentChar))
 }
\ No newline at end of file
@@ -0,0 +1,93 @@
+package gameServer
+
+import (
+	"GridPlay/assert"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// HandleRatings answers GET with "name" and optional "game" query parameters, e.g. /ratings?name=alice&game=gomoku,
+// with rating.Player: the rating of the player in the game and its last changes. The default game is used without "game".
+func (srv *Server) HandleRatings(w http.ResponseWriter, r *http.Request) error {
+	assert.NotNil(srv.ratings, "ratings was nil")
+	assert.NotNil(srv.games, "game registry was nil")
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "ratings can be read with GET", http.StatusMethodNotAllowed)
+		return errors.New("wrong ratings request method")
+	}
+
+	name := strings.TrimSpace(r.URL.Query().Get("name"))
+	if name == "" || name == DefaultPlayerName {
+		http.Error(w, "name of the player is required", http.StatusBadRequest)
+		return errors.New("ratings request without name")
+	}
+
+	game := r.URL.Query().Get("game")
+	if game == "" {
+		game = srv.games.GetDefault()
+	}
+
+	if !srv.games.Has(game) {
+		http.Error(w, "game is not supported", http.StatusBadRequest)
+		return errors.New("ratings request of unknown game")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(srv.ratings.Get(game, name))
+}
+
+const maxNameRequestSize = 1024
+
+type NameRequest struct {
+	Name string `json:"name"`
+}
+
+type NameResponse struct {
+	Name string `json:"name"`
+	Token string `json:"token"`
+}
+
+// HandleNames answers POST with NameRequest body, e.g. {"name": "alice"}, with NameResponse holding the token of the name.
+// Players connect with the name and the token to play ranked games, a name can be claimed once.
+func (srv *Server) HandleNames(w http.ResponseWriter, r *http.Request) error {
+	assert.NotNil(srv.names, "names was nil")
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method == http.MethodOptions {
+		return nil
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "names can be claimed with POST", http.StatusMethodNotAllowed)
+		return errors.New("wrong names request method")
+	}
+
+	var request NameRequest
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxNameRequestSize)).Decode(&request)
+	if err != nil {
+		http.Error(w, "cannot decode names request", http.StatusBadRequest)
+		return err
+	}
+
+	name := normalizeName(request.Name)
+	if name == DefaultPlayerName {
+		http.Error(w, "name of the player is required", http.StatusBadRequest)
+		return errors.New("names request without name")
+	}
+
+	token, err := srv.names.Claim(name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(NameResponse{Name: name, Token: token})
+}
@@ -0,0 +1,104 @@
+package gameServer
+
+import (
+	"GridPlay/assert"
+	"GridPlay/record"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// maxRecordRequestSize fits records of the longest matches, e.g. a full 15x15 board.
+const maxRecordRequestSize = 256 * 1024
+
+// RecordCheck is the answer to an imported record.
+type RecordCheck struct {
+	Moves int `json:"moves"`
+	Result record.Result `json:"result"`
+}
+
+// HandleRecords exports finished matches. GET without "id" lists ids of stored records,
+// GET with "id" returns the record in record.Format notation. POST with a record
+// in the body replays it and answers with RecordCheck, or 400 if it is not valid.
+func (srv *Server) HandleRecords(w http.ResponseWriter, r *http.Request) error {
+	assert.NotNil(srv.records, "record store was nil")
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	switch r.Method {
+	case http.MethodOptions:
+		return nil
+	case http.MethodGet:
+		return srv.exportRecords(w, r)
+	case http.MethodPost:
+		return srv.importRecord(w, r)
+	default:
+		http.Error(w, "records can be read with GET or checked with POST", http.StatusMethodNotAllowed)
+		return errors.New("wrong records request method")
+	}
+}
+
+func (srv *Server) exportRecords(w http.ResponseWriter, r *http.Request) error {
+	idParam := r.URL.Query().Get("id")
+
+	if idParam == "" {
+		w.Header().Set("Content-Type", "application/json")
+		return json.NewEncoder(w).Encode(srv.records.GetIds())
+	}
+
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		http.Error(w, "id must be a room uuid", http.StatusBadRequest)
+		return err
+	}
+
+	rec, err := srv.records.Get(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, err = io.WriteString(w, record.Format(rec))
+
+	return err
+}
+
+func (srv *Server) importRecord(w http.ResponseWriter, r *http.Request) error {
+	assert.NotNil(srv.games, "game registry was nil")
+
+	text, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRecordRequestSize))
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		http.Error(w, "record is too large", http.StatusRequestEntityTooLarge)
+		return err
+	}
+
+	if err != nil {
+		http.Error(w, "cannot read record", http.StatusBadRequest)
+		return err
+	}
+
+	rec, err := record.Parse(string(text))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	_, err = record.Replay(rec, srv.games)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(RecordCheck{
+		Moves: len(rec.Moves),
+		Result: rec.Result,
+	})
+}
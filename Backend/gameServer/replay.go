@@ -0,0 +1,65 @@
+package gameServer
+
+import (
+	"GridPlay/assert"
+	"GridPlay/gameServer/internal/connection"
+	"GridPlay/gameServer/internal/replay"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// HandleReplay streams log of a finished match over WebSocket, e.g. /replay?id=<room uuid>&speed=4.
+func (srv *Server) HandleReplay(w http.ResponseWriter, r *http.Request) error {
+	assert.NotNil(srv.logs, "log store was nil")
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, "id must be a room uuid", http.StatusBadRequest)
+		return err
+	}
+
+	speed, err := parseReplaySpeed(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return err
+	}
+
+	log, err := srv.logs.Get(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return err
+	}
+
+	socket, err := upgrader.Upgrade(w, r, nil)
+	defer r.Body.Close()
+
+	if err != nil {
+		return err
+	}
+
+	conn := connection.CreateConnection(socket)
+	slog.Info("streaming replay", "ip", conn.GetRemoteIP(), "room", id, "speed", speed)
+
+	go replay.Stream(conn, log, speed)
+
+	return nil
+}
+
+func parseReplaySpeed(r *http.Request) (float64, error) {
+	text := r.URL.Query().Get("speed")
+
+	if text == "" {
+		return replay.DefaultSpeed, nil
+	}
+
+	speed, err := strconv.ParseFloat(text, 64)
+	if err != nil || speed <= 0 || speed > replay.MaxSpeed {
+		return 0, errors.New("speed must be a number greater than 0 and at most 100")
+	}
+
+	return speed, nil
+}
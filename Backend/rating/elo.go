@@ -0,0 +1,39 @@
+package rating
+
+import (
+	"GridPlay/assert"
+	"math"
+)
+
+// DefaultK is the largest change of Elo rating after one game.
+const DefaultK = 32.0
+
+// Elo has no deviation, every game changes the rating by at most K points.
+type Elo struct {
+	k float64
+}
+
+func CreateElo(k float64) *Elo {
+	assert.Assert(k > 0, "k must be positive", "k", k)
+
+	return &Elo{
+		k: k,
+	}
+}
+
+func (elo *Elo) GetInitial() Rating {
+	return Rating{
+		Value: DefaultValue,
+	}
+}
+
+func (elo *Elo) Update(ratings [2]Rating, score float64) [2]Rating {
+	expected := 1 / (1 + math.Pow(10, (ratings[1].Value - ratings[0].Value) / 400))
+	change := elo.k * (score - expected)
+
+	updated := ratings
+	updated[0].Value += change
+	updated[1].Value -= change
+
+	return updated
+}
@@ -0,0 +1,156 @@
+package rating
+
+import (
+	"GridPlay/assert"
+	"math"
+)
+
+// Glicko-2 constants, see http://www.glicko.net/glicko/glicko2.pdf.
+const (
+	DefaultDeviation = 350.0
+	DefaultVolatility = 0.06
+	// DefaultTau limits change of volatility, sensible values are between 0.3 and 1.2.
+	DefaultTau = 0.5
+
+	// Ratings are converted to the Glicko-2 scale by this factor.
+	glickoScale = 173.7178
+	convergence = 0.000001
+)
+
+// Glicko2 rates every game as its own rating period.
+type Glicko2 struct {
+	tau float64
+}
+
+func CreateGlicko2(tau float64) *Glicko2 {
+	assert.Assert(tau > 0, "tau must be positive", "tau", tau)
+
+	return &Glicko2{
+		tau: tau,
+	}
+}
+
+func (glicko *Glicko2) GetInitial() Rating {
+	return Rating{
+		Value: DefaultValue,
+		Deviation: DefaultDeviation,
+		Volatility: DefaultVolatility,
+	}
+}
+
+func (glicko *Glicko2) Update(ratings [2]Rating, score float64) [2]Rating {
+	ratings = [2]Rating{glicko.convert(ratings[0]), glicko.convert(ratings[1])}
+
+	return [2]Rating{
+		glicko.updatePlayer(ratings[0], []Rating{ratings[1]}, []float64{score}),
+		glicko.updatePlayer(ratings[1], []Rating{ratings[0]}, []float64{1 - score}),
+	}
+}
+
+// convert gives ratings without deviation, e.g. from Elo, the deviation of a new player.
+// Glicko-2 cannot update ratings with zero deviation or volatility.
+func (glicko *Glicko2) convert(rating Rating) Rating {
+	if rating.Deviation <= 0 {
+		rating.Deviation = DefaultDeviation
+	}
+
+	if rating.Volatility <= 0 {
+		rating.Volatility = DefaultVolatility
+	}
+
+	return rating
+}
+
+// updatePlayer rates the player after games against the opponents in one rating period.
+func (glicko *Glicko2) updatePlayer(player Rating, opponents []Rating, scores []float64) Rating {
+	assert.Assert(len(opponents) == len(scores), "every opponent must have a score")
+
+	mu := (player.Value - DefaultValue) / glickoScale
+	phi := player.Deviation / glickoScale
+
+	if len(opponents) == 0 {
+		deviation := math.Sqrt(phi * phi + player.Volatility * player.Volatility)
+
+		return Rating{
+			Value: player.Value,
+			Deviation: math.Min(deviation * glickoScale, DefaultDeviation),
+			Volatility: player.Volatility,
+		}
+	}
+
+	// Estimated variance v and improvement delta.
+	variance := 0.0
+	improvement := 0.0
+
+	for i, opponent := range opponents {
+		opponentMu := (opponent.Value - DefaultValue) / glickoScale
+		g := reduceImpact(opponent.Deviation / glickoScale)
+		expected := 1 / (1 + math.Exp(-g * (mu - opponentMu)))
+
+		variance += g * g * expected * (1 - expected)
+		improvement += g * (scores[i] - expected)
+	}
+
+	variance = 1 / variance
+	delta := variance * improvement
+
+	volatility := glicko.updateVolatility(phi, player.Volatility, variance, delta)
+
+	phiStar := math.Sqrt(phi * phi + volatility * volatility)
+	newPhi := 1 / math.Sqrt(1 / (phiStar * phiStar) + 1 / variance)
+	newMu := mu + newPhi * newPhi * improvement
+
+	return Rating{
+		Value: newMu * glickoScale + DefaultValue,
+		Deviation: math.Min(newPhi * glickoScale, DefaultDeviation),
+		Volatility: volatility,
+	}
+}
+
+// reduceImpact is g(phi), games against opponents with uncertain ratings count less.
+func reduceImpact(phi float64) float64 {
+	return 1 / math.Sqrt(1 + 3 * phi * phi / (math.Pi * math.Pi))
+}
+
+// updateVolatility finds the new volatility with the Illinois algorithm.
+func (glicko *Glicko2) updateVolatility(phi, sigma, variance, delta float64) float64 {
+	a := math.Log(sigma * sigma)
+	tau := glicko.tau
+
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi * phi + variance + ex
+
+		return ex * (delta * delta - phi * phi - variance - ex) / (2 * d * d) - (x - a) / (tau * tau)
+	}
+
+	A := a
+	var B float64
+
+	if delta * delta > phi * phi + variance {
+		B = math.Log(delta * delta - phi * phi - variance)
+	} else {
+		k := 1.0
+		for f(a - k * tau) < 0 {
+			k++
+		}
+		B = a - k * tau
+	}
+
+	fA, fB := f(A), f(B)
+
+	for math.Abs(B - A) > convergence {
+		C := A + (A - B) * fA / (fB - fA)
+		fC := f(C)
+
+		if fC * fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+
+		B, fB = C, fC
+	}
+
+	return math.Exp(A / 2)
+}
@@ -0,0 +1,62 @@
+package rating
+
+import (
+	"GridPlay/assert"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"sync"
+)
+
+const tokenLength = 16
+
+// Names are claimed by players, the token proves the name in ranked games. It is safe for concurrent use.
+type Names struct {
+	// Hashes of tokens by name, tokens themselves are not kept.
+	tokens map[string][sha256.Size]byte
+	mut sync.Mutex
+}
+
+func CreateNames() *Names {
+	return &Names{
+		tokens: map[string][sha256.Size]byte{},
+	}
+}
+
+// Claim returns token of the name, it fails when the name was already claimed.
+func (names *Names) Claim(name string) (string, error) {
+	assert.Assert(name != "", "name was empty")
+
+	names.mut.Lock()
+	defer names.mut.Unlock()
+
+	if _, ok := names.tokens[name]; ok {
+		return "", errors.New("name is already taken")
+	}
+
+	bytes := make([]byte, tokenLength)
+
+	_, err := rand.Read(bytes)
+	assert.NoError(err, "cannot read random bytes")
+
+	token := hex.EncodeToString(bytes)
+	names.tokens[name] = sha256.Sum256([]byte(token))
+
+	return token, nil
+}
+
+// Verify checks that the token was given for the name.
+func (names *Names) Verify(name, token string) bool {
+	names.mut.Lock()
+	defer names.mut.Unlock()
+
+	hash, ok := names.tokens[name]
+	if !ok {
+		return false
+	}
+
+	tokenHash := sha256.Sum256([]byte(token))
+	return subtle.ConstantTimeCompare(hash[:], tokenHash[:]) == 1
+}
@@ -0,0 +1,25 @@
+package rating
+
+// Rating of players who have not played rated games yet, in every system.
+const DefaultValue = 1500.0
+
+// Rating of a player in one game. Deviation and Volatility are used only by Glicko-2.
+type Rating struct {
+	Value float64 `json:"value"`
+	Deviation float64 `json:"deviation"`
+	Volatility float64 `json:"volatility"`
+}
+
+// System computes new ratings after a game.
+type System interface {
+	// GetInitial returns rating of a new player.
+	GetInitial() Rating
+	// Update returns new ratings of both players, score is of the first player:
+	// 1 for a win, 0.5 for a draw and 0 for a loss.
+	Update(ratings [2]Rating, score float64) [2]Rating
+}
+
+// converter systems adjust ratings computed by another system, see Ratings.SetSystem.
+type converter interface {
+	convert(rating Rating) Rating
+}
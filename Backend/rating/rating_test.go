@@ -0,0 +1,105 @@
+package rating
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Example from the Glicko-2 paper, three games in one rating period.
+func TestGlicko2Example(t *testing.T) {
+	glicko := CreateGlicko2(0.5)
+
+	player := Rating{Value: 1500, Deviation: 200, Volatility: 0.06}
+	opponents := []Rating{
+		{Value: 1400, Deviation: 30},
+		{Value: 1550, Deviation: 100},
+		{Value: 1700, Deviation: 300},
+	}
+
+	updated := glicko.updatePlayer(player, opponents, []float64{1, 0, 0})
+
+	require.InDelta(t, 1464.06, updated.Value, 0.01)
+	require.InDelta(t, 151.52, updated.Deviation, 0.01)
+	require.InDelta(t, 0.05999, updated.Volatility, 0.00001)
+}
+
+func TestGlicko2Update(t *testing.T) {
+	glicko := CreateGlicko2(DefaultTau)
+	initial := glicko.GetInitial()
+
+	updated := glicko.Update([2]Rating{initial, initial}, 1)
+
+	require.Greater(t, updated[0].Value, DefaultValue)
+	require.InDelta(t, DefaultValue - updated[0].Value, updated[1].Value - DefaultValue, 0.000001)
+	require.Less(t, updated[0].Deviation, DefaultDeviation)
+
+	drawn := glicko.Update([2]Rating{initial, initial}, 0.5)
+	require.InDelta(t, DefaultValue, drawn[0].Value, 0.000001)
+}
+
+func TestElo(t *testing.T) {
+	elo := CreateElo(DefaultK)
+
+	updated := elo.Update([2]Rating{{Value: 1500}, {Value: 1500}}, 1)
+	require.Equal(t, 1516.0, updated[0].Value)
+	require.Equal(t, 1484.0, updated[1].Value)
+
+	// Stronger player gains little for a win over weaker one.
+	updated = elo.Update([2]Rating{{Value: 1900}, {Value: 1500}}, 1)
+	require.InDelta(t, 1902.9, updated[0].Value, 0.1)
+}
+
+func TestRatingsUpdate(t *testing.T) {
+	ratings := CreateRatings(CreateElo(DefaultK))
+	now := time.Now()
+
+	changes := ratings.Update("tictactoe", [2]string{"alice", "bob"}, 0, now)
+
+	require.Equal(t, "bob", changes[0].Opponent)
+	require.Equal(t, 1484.0, changes[0].After.Value)
+	require.Equal(t, 1.0, changes[1].Score)
+
+	alice := ratings.Get("tictactoe", "alice")
+	require.Equal(t, 1484.0, alice.Rating.Value)
+	require.Equal(t, 1, alice.Games)
+	require.Len(t, alice.History, 1)
+
+	require.Equal(t, DefaultValue, ratings.Get("gomoku", "alice").Rating.Value)
+	require.Equal(t, 0, ratings.Get("tictactoe", "carol").Games)
+}
+
+func TestNames(t *testing.T) {
+	names := CreateNames()
+
+	token, err := names.Claim("alice")
+	require.NoError(t, err)
+
+	_, err = names.Claim("alice")
+	require.Error(t, err)
+
+	require.True(t, names.Verify("alice", token))
+	require.False(t, names.Verify("alice", "guess"))
+	require.False(t, names.Verify("bob", token))
+}
+
+// Elo ratings have no deviation, Glicko-2 must not get stuck on them.
+func TestGlicko2AfterElo(t *testing.T) {
+	ratings := CreateRatings(CreateElo(DefaultK))
+	now := time.Now()
+
+	ratings.Update("tictactoe", [2]string{"alice", "bob"}, 1, now)
+	ratings.SetSystem(CreateGlicko2(DefaultTau))
+
+	changes := ratings.Update("tictactoe", [2]string{"alice", "bob"}, 1, now)
+	require.Greater(t, changes[0].After.Value, changes[0].Before.Value)
+	require.Equal(t, DefaultVolatility, changes[0].Before.Volatility)
+
+	alice := ratings.Get("tictactoe", "alice")
+	require.Greater(t, alice.Rating.Deviation, 0.0)
+	require.Greater(t, alice.Rating.Volatility, 0.0)
+
+	changes = ratings.Update("tictactoe", [2]string{"alice", "bob"}, 0, now)
+	require.Less(t, changes[0].After.Value, changes[0].Before.Value)
+}
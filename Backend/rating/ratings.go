@@ -0,0 +1,133 @@
+package rating
+
+import (
+	"GridPlay/assert"
+	"sync"
+	"time"
+)
+
+// MaxHistory is the number of last rating changes kept for every player and game.
+const MaxHistory = 100
+
+// Change of the player's rating after one game.
+type Change struct {
+	Time time.Time `json:"time"`
+	Opponent string `json:"opponent"`
+	Score float64 `json:"score"`
+	Before Rating `json:"before"`
+	After Rating `json:"after"`
+}
+
+// Player has rating and its history in one game.
+type Player struct {
+	Name string `json:"name"`
+	Game string `json:"game"`
+	Rating Rating `json:"rating"`
+	Games int `json:"games"`
+	History []Change `json:"history"`
+}
+
+type playerKey struct {
+	game string
+	name string
+}
+
+// Ratings keeps ratings of players by name, separately for every game. It is safe for concurrent use.
+type Ratings struct {
+	system System
+	players map[playerKey]*Player
+	mut sync.Mutex
+}
+
+func CreateRatings(system System) *Ratings {
+	assert.NotNil(system, "rating system was nil")
+
+	return &Ratings{
+		system: system,
+		players: map[playerKey]*Player{},
+	}
+}
+
+// SetSystem changes how new games are rated. Rating values of players are kept,
+// values the new system needs, e.g. deviation of Glicko-2 after Elo, start as for new players.
+func (ratings *Ratings) SetSystem(system System) {
+	assert.NotNil(system, "rating system was nil")
+
+	ratings.mut.Lock()
+	defer ratings.mut.Unlock()
+
+	ratings.system = system
+
+	c, ok := system.(converter)
+	if !ok {
+		return
+	}
+
+	for _, player := range ratings.players {
+		player.Rating = c.convert(player.Rating)
+	}
+}
+
+// Get returns copy of the player, with initial rating if the player has not played the game.
+func (ratings *Ratings) Get(game, name string) Player {
+	ratings.mut.Lock()
+	defer ratings.mut.Unlock()
+
+	player := *ratings.getPlayer(game, name)
+	player.History = append([]Change{}, player.History...)
+
+	return player
+}
+
+// Update rates a game of the players, score is of the first player. It returns changes of both players.
+func (ratings *Ratings) Update(game string, names [2]string, score float64, now time.Time) [2]Change {
+	assert.Assert(names[0] != names[1], "player cannot play against itself", "name", names[0])
+	assert.Assert(score >= 0 && score <= 1, "score out of range", "score", score)
+
+	ratings.mut.Lock()
+	defer ratings.mut.Unlock()
+
+	players := [2]*Player{ratings.getPlayer(game, names[0]), ratings.getPlayer(game, names[1])}
+	before := [2]Rating{players[0].Rating, players[1].Rating}
+	after := ratings.system.Update(before, score)
+	scores := [2]float64{score, 1 - score}
+
+	var changes [2]Change
+
+	for i, player := range players {
+		changes[i] = Change{
+			Time: now,
+			Opponent: names[1 - i],
+			Score: scores[i],
+			Before: before[i],
+			After: after[i],
+		}
+
+		player.Rating = after[i]
+		player.Games++
+		player.History = append(player.History, changes[i])
+
+		if len(player.History) > MaxHistory {
+			player.History = player.History[1:]
+		}
+
+		ratings.players[playerKey{game, player.Name}] = player
+	}
+
+	return changes
+}
+
+func (ratings *Ratings) getPlayer(game, name string) *Player {
+	player, ok := ratings.players[playerKey{game, name}]
+
+	if !ok {
+		return &Player{
+			Name: name,
+			Game: game,
+			Rating: ratings.system.GetInitial(),
+			History: []Change{},
+		}
+	}
+
+	return player
+}
@@ -0,0 +1,267 @@
+package record
+
+import (
+	"GridPlay/assert"
+	"GridPlay/game/winState"
+	"GridPlay/gameRules"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Result is written as in PGN, from the point of view of player 0.
+type Result string
+
+const (
+	ResultFirstWin Result = "1-0"
+	ResultSecondWin Result = "0-1"
+	ResultDraw Result = "1/2-1/2"
+	ResultUnfinished Result = "*"
+)
+
+// Termination is the cause of the game end.
+type Termination string
+
+const (
+	TerminationNormal Termination = "normal"
+	TerminationDisconnect Termination = "disconnect"
+	TerminationTimeout Termination = "timeout"
+	TerminationResignation Termination = "resignation"
+	TerminationAgreement Termination = "agreement"
+)
+
+// Record of a match, the moves are client move messages, so they can be replayed through game rules.
+type Record struct {
+	Game string
+	Params gameRules.Params
+	Players [2]string
+	StartTime time.Time
+	Result Result
+	Termination Termination
+	Moves []json.RawMessage
+}
+
+func CreateRecord(game string, params gameRules.Params, players [2]string, startTime time.Time) *Record {
+	return &Record{
+		Game: game,
+		Params: params,
+		Players: players,
+		StartTime: startTime,
+		Result: ResultUnfinished,
+		Moves: []json.RawMessage{},
+	}
+}
+
+// AddMove appends move data, compacted so it has no white space.
+func (record *Record) AddMove(data []byte) error {
+	var move bytes.Buffer
+
+	err := json.Compact(&move, data)
+	if err != nil {
+		return errors.New("move is not valid json")
+	}
+
+	record.Moves = append(record.Moves, move.Bytes())
+	return nil
+}
+
+// RemoveMoves removes count last moves, e.g. after take-back.
+func (record *Record) RemoveMoves(count int) {
+	assert.Assert(count <= len(record.Moves), "cannot remove more moves than recorded", "count", count)
+
+	record.Moves = record.Moves[:len(record.Moves) - count]
+}
+
+func (record *Record) Finish(result Result, termination Termination) {
+	assert.Assert(record.Result == ResultUnfinished, "record was already finished")
+
+	record.Result = result
+	record.Termination = termination
+}
+
+func ResultOfWinner(playerId int) Result {
+	if playerId == 0 {
+		return ResultFirstWin
+	}
+
+	return ResultSecondWin
+}
+
+func ResultFromOutcome(outcome winState.WinState) Result {
+	if winState.IsWin(outcome) {
+		return ResultOfWinner(outcome.GetPlayer().Id)
+	}
+
+	if outcome == winState.Values.Draw {
+		return ResultDraw
+	}
+
+	return ResultUnfinished
+}
+
+// Format writes the record as tag pairs and move text, similar to PGN:
+//
+//	[Game "tictactoe"]
+//	[Params "height=3 width=3"]
+//	...
+//
+//	1. {"x":0,"y":0} {"x":1,"y":0}
+//	2. {"x":1,"y":1} 1-0
+func Format(record *Record) string {
+	var text strings.Builder
+
+	writeTag := func(name, value string) {
+		fmt.Fprintf(&text, "[%s %s]\n", name, strconv.Quote(value))
+	}
+
+	writeTag("Game", record.Game)
+	writeTag("Params", formatParams(record.Params))
+	writeTag("Player0", record.Players[0])
+	writeTag("Player1", record.Players[1])
+	writeTag("StartTime", record.StartTime.UTC().Format(time.RFC3339))
+	writeTag("Result", string(record.Result))
+	writeTag("Termination", string(record.Termination))
+	text.WriteString("\n")
+
+	for i, move := range record.Moves {
+		if i % 2 == 0 {
+			if i > 0 {
+				text.WriteString("\n")
+			}
+			fmt.Fprintf(&text, "%d. ", i / 2 + 1)
+		} else {
+			text.WriteString(" ")
+		}
+
+		text.Write(move)
+	}
+
+	if len(record.Moves) > 0 {
+		text.WriteString(" ")
+	}
+
+	text.WriteString(string(record.Result))
+	text.WriteString("\n")
+
+	return text.String()
+}
+
+var tagPattern = regexp.MustCompile(`^\[(\w+) (".*")\]$`)
+var moveNumberPattern = regexp.MustCompile(`^\d+\.$`)
+
+// Parse reads a record written by Format. It does not check if the moves are legal, see Replay.
+func Parse(text string) (*Record, error) {
+	record := &Record{
+		Moves: []json.RawMessage{},
+	}
+
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	i := 0
+
+	for ; i < len(lines) && strings.HasPrefix(lines[i], "["); i++ {
+		match := tagPattern.FindStringSubmatch(strings.TrimSpace(lines[i]))
+		if match == nil {
+			return nil, fmt.Errorf("line %d is not a tag", i + 1)
+		}
+
+		value, err := strconv.Unquote(match[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d has invalid tag value", i + 1)
+		}
+
+		err = record.setTag(match[1], value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if record.Game == "" || record.Result == "" {
+		return nil, errors.New("record must have Game and Result tags")
+	}
+
+	tokens := strings.Fields(strings.Join(lines[i:], " "))
+
+	if len(tokens) == 0 || Result(tokens[len(tokens) - 1]) != record.Result {
+		return nil, errors.New("move text must end with the result")
+	}
+
+	for _, token := range tokens[:len(tokens) - 1] {
+		if moveNumberPattern.MatchString(token) {
+			continue
+		}
+
+		err := record.AddMove([]byte(token))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return record, nil
+}
+
+func (record *Record) setTag(name, value string) error {
+	var err error
+
+	switch name {
+	case "Game":
+		record.Game = value
+	case "Params":
+		record.Params, err = parseParams(value)
+	case "Player0":
+		record.Players[0] = value
+	case "Player1":
+		record.Players[1] = value
+	case "StartTime":
+		record.StartTime, err = time.Parse(time.RFC3339, value)
+	case "Result":
+		record.Result = Result(value)
+	case "Termination":
+		record.Termination = Termination(value)
+	default:
+		// Unknown tags are skipped, as in PGN.
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid %s tag", name)
+	}
+
+	return nil
+}
+
+// formatParams writes params sorted by name, e.g. "height=3 width=3".
+func formatParams(params gameRules.Params) string {
+	pairs := make([]string, 0, len(params))
+
+	for name, value := range params {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", name, value))
+	}
+
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
+
+func parseParams(text string) (gameRules.Params, error) {
+	params := gameRules.Params{}
+
+	for _, pair := range strings.Fields(text) {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, errors.New("param must be name=value")
+		}
+
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.New("param value must be a number")
+		}
+
+		params[name] = number
+	}
+
+	return params, nil
+}
@@ -0,0 +1,122 @@
+package record
+
+import (
+	"GridPlay/connectFour"
+	"GridPlay/game"
+	"GridPlay/gameRules"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createGames() *gameRules.Registry {
+	games := gameRules.CreateRegistry()
+	games.Register(game.Name, game.CreateRules)
+	games.Register(connectFour.Name, connectFour.CreateRules)
+
+	return games
+}
+
+func createRecord(t *testing.T, moves ...string) *Record {
+	start := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	record := CreateRecord(game.Name, gameRules.Params{game.ParamWidth: 3}, [2]string{"alice", "bot"}, start)
+
+	for _, move := range moves {
+		require.NoError(t, record.AddMove([]byte(move)))
+	}
+
+	return record
+}
+
+func TestFormatParse(t *testing.T) {
+	record := createRecord(t, `{"x": 0, "y": 0}`, `{"x":1,"y":0}`, `{"x":1,"y":1}`, `{"x":2,"y":1}`, `{"x":2,"y":2}`)
+	record.Finish(ResultFirstWin, TerminationNormal)
+
+	text := Format(record)
+	require.Equal(t, `[Game "tictactoe"]
+[Params "width=3"]
+[Player0 "alice"]
+[Player1 "bot"]
+[StartTime "2024-05-01T12:30:00Z"]
+[Result "1-0"]
+[Termination "normal"]
+
+1. {"x":0,"y":0} {"x":1,"y":0}
+2. {"x":1,"y":1} {"x":2,"y":1}
+3. {"x":2,"y":2} 1-0
+`, text)
+
+	parsed, err := Parse(text)
+	require.NoError(t, err)
+	require.Equal(t, record, parsed)
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"[Game \"tictactoe\"]\n\n*",
+		"[Game \"tictactoe\"]\n[Result \"*\"]\n\n1. {\"x\":0,\"y\":0} 1-0",
+		"[Game tictactoe]\n[Result \"*\"]\n\n*",
+		"[Game \"tictactoe\"]\n[Params \"width\"]\n[Result \"*\"]\n\n*",
+		"[Game \"tictactoe\"]\n[Result \"*\"]\n\n1. {\"x\":0 *",
+	} {
+		_, err := Parse(text)
+		require.Error(t, err, text)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	games := createGames()
+
+	record := createRecord(t, `{"x":0,"y":0}`, `{"x":1,"y":0}`, `{"x":1,"y":1}`, `{"x":2,"y":1}`, `{"x":2,"y":2}`)
+	record.Finish(ResultFirstWin, TerminationNormal)
+
+	rules, err := Replay(record, games)
+	require.NoError(t, err)
+	require.Equal(t, 0, rules.GetOutcome().GetPlayer().Id)
+
+	// Wrong result.
+	record.Result = ResultDraw
+	_, err = Replay(record, games)
+	require.Error(t, err)
+
+	// Game was won on the board, so it did not end by disconnect.
+	record.Result = ResultFirstWin
+	record.Termination = TerminationDisconnect
+	_, err = Replay(record, games)
+	require.Error(t, err)
+
+	record.RemoveMoves(1)
+	_, err = Replay(record, games)
+	require.NoError(t, err)
+}
+
+func TestReplayIllegalMove(t *testing.T) {
+	games := createGames()
+
+	record := createRecord(t, `{"x":0,"y":0}`, `{"x":0,"y":0}`)
+	record.Finish(ResultSecondWin, TerminationDisconnect)
+
+	_, err := Replay(record, games)
+	require.ErrorContains(t, err, "move 2")
+
+	record.Game = "chess"
+	_, err = Replay(record, games)
+	require.Error(t, err)
+}
+
+func TestReplayConnectFour(t *testing.T) {
+	record := CreateRecord(connectFour.Name, nil, [2]string{"a", "b"}, time.Now())
+
+	for _, column := range []string{"0", "1", "0", "1", "0", "1", "0"} {
+		require.NoError(t, record.AddMove([]byte(`{"column":` + column + `}`)))
+	}
+	record.Finish(ResultFirstWin, TerminationNormal)
+
+	parsed, err := Parse(Format(record))
+	require.NoError(t, err)
+
+	_, err = Replay(parsed, createGames())
+	require.NoError(t, err)
+}
@@ -0,0 +1,61 @@
+package record
+
+import (
+	"GridPlay/assert"
+	"GridPlay/game/winState"
+	"GridPlay/gameRules"
+	"errors"
+	"fmt"
+)
+
+// Replay plays the record through the game rules and checks that every move
+// is legal and the result matches the game. It returns the final game.
+func Replay(record *Record, games *gameRules.Registry) (gameRules.GameRules, error) {
+	assert.NotNil(record, "record was nil")
+	assert.NotNil(games, "game registry was nil")
+
+	rules, err := games.Create(record.Game, record.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, data := range record.Moves {
+		if rules.GetOutcome() != winState.Values.None {
+			return nil, fmt.Errorf("move %d was played after the game ended", i + 1)
+		}
+
+		move, err := rules.DecodeMove(data)
+		if err != nil {
+			return nil, fmt.Errorf("move %d: %w", i + 1, err)
+		}
+
+		_, err = rules.ApplyMove(rules.GetCurrentPlayer(), move)
+		if err != nil {
+			return nil, fmt.Errorf("move %d: %w", i + 1, err)
+		}
+	}
+
+	err = checkResult(record, rules.GetOutcome())
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func checkResult(record *Record, outcome winState.WinState) error {
+	if record.Termination == TerminationNormal {
+		if ResultFromOutcome(outcome) != record.Result {
+			return errors.New("result does not match the final position")
+		}
+
+		return nil
+	}
+
+	// Game was stopped before its end, e.g. by disconnect.
+	if outcome != winState.Values.None {
+		return errors.New("game has ended on the board, but termination is not normal")
+	}
+
+	return nil
+}
@@ -0,0 +1,324 @@
+package reversi
+
+import (
+	"GridPlay/assert"
+	"GridPlay/game"
+	"GridPlay/game/winState"
+	"GridPlay/gameRules"
+	"encoding/json"
+	"errors"
+	"slices"
+)
+
+const (
+	Name = "reversi"
+	DefaultSize = 8
+	MinSize = 4
+	MaxSize = 16
+)
+
+const ParamSize = "size"
+
+const empty = -1
+
+// Black moves first.
+var symbols = [2]rune{'b', 'w'}
+
+var directions = [8]game.Pos{
+	{X: 1, Y: 0}, {X: -1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: -1},
+	{X: 1, Y: 1}, {X: 1, Y: -1}, {X: -1, Y: 1}, {X: -1, Y: -1},
+}
+
+// MoveResult is the placed disc with all flipped cells. When the next player
+// had no legal move, Passed is set and NextPlayer moves again.
+type MoveResult struct {
+	game.Pos
+	Flipped []game.Pos `json:"flipped"`
+	Passed bool `json:"passed"`
+	NextPlayer int `json:"nextPlayer"`
+	Score [2]int `json:"score"`
+}
+
+type State struct {
+	// Board[x][y], ' ' is an empty cell.
+	Board [][]rune `json:"board"`
+	Size int `json:"size"`
+	Score [2]int `json:"score"`
+	CurrentPlayer int `json:"currentPlayer"`
+}
+
+type Rules struct {
+	cells [][]int
+	size int
+	currentPlayer int
+	moveNumber int
+	winState winState.WinState
+}
+
+func CreateRules(params gameRules.Params) (gameRules.GameRules, error) {
+	size := params.Get(ParamSize, DefaultSize)
+
+	if size < MinSize || size > MaxSize || size % 2 != 0 {
+		return nil, errors.New("board size must be even and between 4 and 16")
+	}
+
+	rules := &Rules{
+		cells: make([][]int, size),
+		size: size,
+		currentPlayer: 0,
+		winState: winState.Values.None,
+	}
+
+	for x := range rules.cells {
+		rules.cells[x] = make([]int, size)
+
+		for y := range rules.cells[x] {
+			rules.cells[x][y] = empty
+		}
+	}
+
+	mid := size / 2
+	rules.cells[mid - 1][mid - 1] = 1
+	rules.cells[mid][mid] = 1
+	rules.cells[mid - 1][mid] = 0
+	rules.cells[mid][mid - 1] = 0
+
+	return rules, nil
+}
+
+func (rules *Rules) GetName() string {
+	return Name
+}
+
+func (rules *Rules) GetParams() gameRules.Params {
+	return gameRules.Params{
+		ParamSize: rules.size,
+	}
+}
+
+func (rules *Rules) DecodeMove(data []byte) (gameRules.Move, error) {
+	var pos game.Pos
+	err := json.Unmarshal(data, &pos)
+
+	if err != nil {
+		return nil, errors.New("move must be a position")
+	}
+
+	return pos, nil
+}
+
+func (rules *Rules) ValidateMove(playerId int, move gameRules.Move) error {
+	pos, ok := move.(game.Pos)
+	assert.Assert(ok, "type assertion failed for move pos")
+
+	if rules.winState != winState.Values.None {
+		return errors.New("cannot move after game ended")
+	}
+
+	if rules.currentPlayer != playerId {
+		return errors.New("not your round")
+	}
+
+	if !rules.inBounds(pos) {
+		return errors.New("position is out of range")
+	}
+
+	if rules.cells[pos.X][pos.Y] != empty {
+		return errors.New("cell is not empty")
+	}
+
+	if len(rules.flips(pos, playerId)) == 0 {
+		return errors.New("move must flip at least one disc")
+	}
+
+	return nil
+}
+
+func (rules *Rules) ApplyMove(playerId int, move gameRules.Move) (gameRules.MoveResult, error) {
+	err := rules.ValidateMove(playerId, move)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pos := move.(game.Pos)
+	flipped := rules.flips(pos, playerId)
+
+	rules.cells[pos.X][pos.Y] = playerId
+	for _, f := range flipped {
+		rules.cells[f.X][f.Y] = playerId
+	}
+
+	rules.moveNumber++
+	passed := rules.nextTurn(playerId)
+
+	return MoveResult{
+		Pos: pos,
+		Flipped: flipped,
+		Passed: passed,
+		NextPlayer: rules.currentPlayer,
+		Score: rules.GetScore(),
+	}, nil
+}
+
+// nextTurn gives the turn to the opponent, or back to the player when opponent
+// has to pass. Returns true if opponent passed. Ends the game when nobody can move.
+func (rules *Rules) nextTurn(playerId int) bool {
+	opponentId := 1 - playerId
+
+	if rules.HasLegalMove(opponentId) {
+		rules.currentPlayer = opponentId
+		return false
+	}
+
+	if rules.HasLegalMove(playerId) {
+		rules.currentPlayer = playerId
+		return true
+	}
+
+	rules.winState = rules.scoreOutcome()
+	rules.currentPlayer = opponentId
+	return false
+}
+
+func (rules *Rules) scoreOutcome() winState.WinState {
+	score := rules.GetScore()
+
+	if score[0] == score[1] {
+		return winState.Values.Draw
+	}
+
+	winner := 0
+	if score[1] > score[0] {
+		winner = 1
+	}
+
+	return winState.CreateWin(winState.Player{
+		Id: winner,
+		Char: int(symbols[winner]),
+	})
+}
+
+// flips returns discs that would be flipped by playerId placing a disc at pos.
+func (rules *Rules) flips(pos game.Pos, playerId int) []game.Pos {
+	flipped := []game.Pos{}
+
+	for _, dir := range directions {
+		line := []game.Pos{}
+		curr := game.Pos{X: pos.X + dir.X, Y: pos.Y + dir.Y}
+
+		for rules.inBounds(curr) && rules.cells[curr.X][curr.Y] == 1 - playerId {
+			line = append(line, curr)
+			curr = game.Pos{X: curr.X + dir.X, Y: curr.Y + dir.Y}
+		}
+
+		if rules.inBounds(curr) && rules.cells[curr.X][curr.Y] == playerId {
+			flipped = append(flipped, line...)
+		}
+	}
+
+	return flipped
+}
+
+func (rules *Rules) GetLegalPositions(playerId int) []game.Pos {
+	legal := []game.Pos{}
+
+	for x := range rules.size {
+		for y := range rules.size {
+			pos := game.Pos{X: x, Y: y}
+
+			if rules.cells[x][y] == empty && len(rules.flips(pos, playerId)) > 0 {
+				legal = append(legal, pos)
+			}
+		}
+	}
+
+	return legal
+}
+
+func (rules *Rules) HasLegalMove(playerId int) bool {
+	return len(rules.GetLegalPositions(playerId)) > 0
+}
+
+func (rules *Rules) GetScore() [2]int {
+	var score [2]int
+
+	for _, column := range rules.cells {
+		for _, id := range column {
+			if id != empty {
+				score[id]++
+			}
+		}
+	}
+
+	return score
+}
+
+func (rules *Rules) inBounds(pos game.Pos) bool {
+	return pos.X >= 0 && pos.Y >= 0 && pos.X < rules.size && pos.Y < rules.size
+}
+
+func (rules *Rules) GetCurrentPlayer() int {
+	return rules.currentPlayer
+}
+
+func (rules *Rules) GetOutcome() winState.WinState {
+	return rules.winState
+}
+
+func (rules *Rules) GetState() any {
+	board := make([][]rune, rules.size)
+
+	for x := range rules.size {
+		board[x] = make([]rune, rules.size)
+
+		for y := range rules.size {
+			board[x][y] = ' '
+			if id := rules.cells[x][y]; id != empty {
+				board[x][y] = symbols[id]
+			}
+		}
+	}
+
+	return State{
+		Board: board,
+		Size: rules.size,
+		Score: rules.GetScore(),
+		CurrentPlayer: rules.currentPlayer,
+	}
+}
+
+func (rules *Rules) GetPlayerSymbol(playerId int) rune {
+	assert.Assert(playerId == 0 || playerId == 1, "player id must be 0 or 1", "player id", playerId)
+
+	return symbols[playerId]
+}
+
+func (rules *Rules) GetMoveNumber() int {
+	return rules.moveNumber
+}
+
+func (rules *Rules) Clone() gameRules.Searchable {
+	clone := *rules
+	clone.cells = make([][]int, rules.size)
+
+	for x := range rules.cells {
+		clone.cells[x] = slices.Clone(rules.cells[x])
+	}
+
+	return &clone
+}
+
+func (rules *Rules) GetLegalMoves() []gameRules.Move {
+	moves := []gameRules.Move{}
+
+	if rules.winState != winState.Values.None {
+		return moves
+	}
+
+	for _, pos := range rules.GetLegalPositions(rules.currentPlayer) {
+		moves = append(moves, pos)
+	}
+
+	return moves
+}
@@ -0,0 +1,91 @@
+package reversi
+
+import (
+	"GridPlay/game"
+	"GridPlay/game/winState"
+	"GridPlay/gameRules"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createTestRules(t *testing.T, size int) *Rules {
+	rules, err := CreateRules(map[string]int{ParamSize: size})
+	require.NoError(t, err)
+
+	return rules.(*Rules)
+}
+
+func clearBoard(rules *Rules) {
+	for x := range rules.cells {
+		for y := range rules.cells[x] {
+			rules.cells[x][y] = empty
+		}
+	}
+}
+
+func TestReversiOpening(t *testing.T) {
+	rules := createTestRules(t, DefaultSize)
+
+	require.ElementsMatch(t, []game.Pos{{X: 2, Y: 3}, {X: 3, Y: 2}, {X: 4, Y: 5}, {X: 5, Y: 4}}, rules.GetLegalPositions(0))
+	require.Error(t, rules.ValidateMove(0, game.Pos{X: 0, Y: 0}))
+	require.Error(t, rules.ValidateMove(1, game.Pos{X: 2, Y: 3}))
+
+	result, err := rules.ApplyMove(0, game.Pos{X: 2, Y: 3})
+	require.NoError(t, err)
+
+	moveResult := result.(MoveResult)
+	require.Equal(t, []game.Pos{{X: 3, Y: 3}}, moveResult.Flipped)
+	require.False(t, moveResult.Passed)
+	require.Equal(t, 1, moveResult.NextPlayer)
+	require.Equal(t, [2]int{4, 1}, moveResult.Score)
+}
+
+func TestReversiMultiFlip(t *testing.T) {
+	rules := createTestRules(t, 4)
+	clearBoard(rules)
+	rules.cells[0][0] = 0
+	rules.cells[1][0] = 1
+	rules.cells[2][0] = 1
+	rules.cells[3][2] = 0
+	rules.cells[3][1] = 1
+
+	result, err := rules.ApplyMove(0, game.Pos{X: 3, Y: 0})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []game.Pos{{X: 1, Y: 0}, {X: 2, Y: 0}, {X: 3, Y: 1}}, result.(MoveResult).Flipped)
+}
+
+func TestReversiPassAndScoreWin(t *testing.T) {
+	rules := createTestRules(t, 4)
+	clearBoard(rules)
+	rules.cells[0][0] = 0
+	rules.cells[1][0] = 1
+	rules.cells[0][3] = 0
+	rules.cells[1][3] = 1
+
+	result, err := rules.ApplyMove(0, game.Pos{X: 2, Y: 0})
+	require.NoError(t, err)
+	require.True(t, result.(MoveResult).Passed)
+	require.Equal(t, 0, rules.GetCurrentPlayer())
+	require.Equal(t, winState.Values.None, rules.GetOutcome())
+
+	_, err = rules.ApplyMove(0, game.Pos{X: 2, Y: 3})
+	require.NoError(t, err)
+
+	outcome := rules.GetOutcome()
+	require.True(t, winState.IsWin(outcome))
+	require.Equal(t, 0, outcome.GetPlayer().Id)
+	require.Equal(t, [2]int{6, 0}, rules.GetScore())
+}
+
+func TestReversiSize(t *testing.T) {
+	_, err := CreateRules(map[string]int{ParamSize: 5})
+	require.Error(t, err)
+
+	_, err = CreateRules(map[string]int{ParamSize: 18})
+	require.Error(t, err)
+
+	rules, err := CreateRules(nil)
+	require.NoError(t, err)
+	require.Equal(t, gameRules.Params{ParamSize: DefaultSize}, rules.(gameRules.Parametrized).GetParams())
+}
@@ -27,6 +27,66 @@ func handleConnections(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func handleAnalysis(w http.ResponseWriter, r *http.Request) {
+	assert.NotNil(srv, "server was nil")
+
+	err := srv.HandleAnalysis(w, r)
+
+	if err != nil {
+		slog.Warn("cannot analyse position", "error", err)
+	}
+}
+
+func handleRecords(w http.ResponseWriter, r *http.Request) {
+	assert.NotNil(srv, "server was nil")
+
+	err := srv.HandleRecords(w, r)
+
+	if err != nil {
+		slog.Warn("cannot handle records request", "error", err)
+	}
+}
+
+func handleReplay(w http.ResponseWriter, r *http.Request) {
+	assert.NotNil(srv, "server was nil")
+
+	err := srv.HandleReplay(w, r)
+
+	if err != nil {
+		slog.Warn("cannot stream replay", "error", err)
+	}
+}
+
+func handleRooms(w http.ResponseWriter, r *http.Request) {
+	assert.NotNil(srv, "server was nil")
+
+	err := srv.HandleRooms(w, r)
+
+	if err != nil {
+		slog.Warn("cannot list rooms", "error", err)
+	}
+}
+
+func handleRatings(w http.ResponseWriter, r *http.Request) {
+	assert.NotNil(srv, "server was nil")
+
+	err := srv.HandleRatings(w, r)
+
+	if err != nil {
+		slog.Warn("cannot send rating", "error", err)
+	}
+}
+
+func handleNames(w http.ResponseWriter, r *http.Request) {
+	assert.NotNil(srv, "server was nil")
+
+	err := srv.HandleNames(w, r)
+
+	if err != nil {
+		slog.Warn("cannot claim name", "error", err)
+	}
+}
+
 func loop() {
 	assert.NotNil(srv, "server was nil")
 
@@ -77,6 +137,12 @@ func main() {
 
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 	http.HandleFunc("/ws", handleConnections)
+	http.HandleFunc("/analysis", handleAnalysis)
+	http.HandleFunc("/records", handleRecords)
+	http.HandleFunc("/replay", handleReplay)
+	http.HandleFunc("/rooms", handleRooms)
+	http.HandleFunc("/ratings", handleRatings)
+	http.HandleFunc("/names", handleNames)
 
 	e := http.ListenAndServe(":4000", nil)
 
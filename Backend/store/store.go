@@ -0,0 +1,72 @@
+package store
+
+import (
+	"GridPlay/assert"
+	"errors"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const DefaultSize = 1000
+
+// Store keeps items of the last finished matches by room uuid, the oldest are dropped when it is full.
+type Store[T any] struct {
+	items map[uuid.UUID]T
+	// Ids from the oldest item.
+	order []uuid.UUID
+	size int
+	mut sync.Mutex
+}
+
+func CreateStore[T any](size int) *Store[T] {
+	assert.Assert(size > 0, "store size must be positive", "size", size)
+
+	return &Store[T]{
+		items: make(map[uuid.UUID]T),
+		order: []uuid.UUID{},
+		size: size,
+	}
+}
+
+func (store *Store[T]) Add(id uuid.UUID, item T) {
+	assert.NotNil(item, "item was nil")
+
+	store.mut.Lock()
+	defer store.mut.Unlock()
+
+	_, exists := store.items[id]
+	assert.Assert(!exists, "item already exists", "id", id)
+
+	if len(store.order) == store.size {
+		delete(store.items, store.order[0])
+		store.order = store.order[1:]
+	}
+
+	store.items[id] = item
+	store.order = append(store.order, id)
+}
+
+func (store *Store[T]) Get(id uuid.UUID) (T, error) {
+	store.mut.Lock()
+	defer store.mut.Unlock()
+
+	item, ok := store.items[id]
+
+	if !ok {
+		return item, errors.New("item does not exist")
+	}
+
+	return item, nil
+}
+
+// GetIds returns ids of all stored items, from the oldest.
+func (store *Store[T]) GetIds() []uuid.UUID {
+	store.mut.Lock()
+	defer store.mut.Unlock()
+
+	ids := make([]uuid.UUID, len(store.order))
+	copy(ids, store.order)
+
+	return ids
+}
@@ -0,0 +1,27 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	store := CreateStore[*string](2)
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+	for i, id := range ids {
+		item := string(rune('a' + i))
+		store.Add(id, &item)
+	}
+
+	require.Equal(t, ids[1:], store.GetIds())
+
+	_, err := store.Get(ids[0])
+	require.Error(t, err)
+
+	item, err := store.Get(ids[2])
+	require.NoError(t, err)
+	require.Equal(t, "c", *item)
+}
@@ -0,0 +1,363 @@
+package ultimate
+
+import (
+	"GridPlay/assert"
+	"GridPlay/game"
+	"GridPlay/game/winState"
+	"GridPlay/gameRules"
+	"encoding/json"
+	"errors"
+)
+
+const (
+	Name = "ultimate"
+	// Size of sub-board and of the meta-board.
+	Size = 3
+	BoardSize = Size * Size
+)
+
+const empty = -1
+
+// SubBoard describes outcome of one of nine boards.
+type SubBoard struct {
+	Board game.Pos `json:"board"`
+	Status string `json:"status"`
+	Winner *int `json:"winner,omitempty"`
+}
+
+// MoveResult is the played cell in global coordinates (0-8), outcome of its sub-board
+// and sub-boards where the next player is allowed to play.
+type MoveResult struct {
+	game.Pos
+	SubBoard SubBoard `json:"subBoard"`
+	LegalBoards []game.Pos `json:"legalBoards"`
+}
+
+type State struct {
+	// Board[x][y] in global coordinates, ' ' is an empty cell.
+	Board [][]rune `json:"board"`
+	SubBoards []SubBoard `json:"subBoards"`
+	LegalBoards []game.Pos `json:"legalBoards"`
+	CurrentPlayer int `json:"currentPlayer"`
+}
+
+// Rules of ultimate tic-tac-toe. Cell played inside a sub-board points
+// to the sub-board where the opponent has to play next.
+// If that sub-board is finished, opponent can play in any unfinished one.
+type Rules struct {
+	cells [BoardSize][BoardSize]int
+	subBoards [Size][Size]winState.WinState
+	// Sub-board where current player must play, nil means any.
+	forcedBoard *game.Pos
+	symbols [2]rune
+	currentPlayer int
+	moveNumber int
+	winState winState.WinState
+}
+
+func CreateRules(params gameRules.Params) (gameRules.GameRules, error) {
+	char := game.RandomChar()
+
+	rules := &Rules{
+		symbols: [2]rune{char.GetRune(), game.OpponentChar(char).GetRune()},
+		currentPlayer: 0,
+		winState: winState.Values.None,
+	}
+
+	for x := range BoardSize {
+		for y := range BoardSize {
+			rules.cells[x][y] = empty
+		}
+	}
+
+	for x := range Size {
+		for y := range Size {
+			rules.subBoards[x][y] = winState.Values.None
+		}
+	}
+
+	return rules, nil
+}
+
+func (rules *Rules) GetName() string {
+	return Name
+}
+
+func (rules *Rules) DecodeMove(data []byte) (gameRules.Move, error) {
+	var pos game.Pos
+	err := json.Unmarshal(data, &pos)
+
+	if err != nil {
+		return nil, errors.New("move must be a position")
+	}
+
+	return pos, nil
+}
+
+func (rules *Rules) ValidateMove(playerId int, move gameRules.Move) error {
+	pos, ok := move.(game.Pos)
+	assert.Assert(ok, "type assertion failed for move pos")
+
+	if rules.winState != winState.Values.None {
+		return errors.New("cannot move after game ended")
+	}
+
+	if rules.currentPlayer != playerId {
+		return errors.New("not your round")
+	}
+
+	if pos.X < 0 || pos.Y < 0 || pos.X >= BoardSize || pos.Y >= BoardSize {
+		return errors.New("position is out of range")
+	}
+
+	board := boardOf(pos)
+
+	if rules.subBoards[board.X][board.Y] != winState.Values.None {
+		return errors.New("sub-board is already finished")
+	}
+
+	if rules.forcedBoard != nil && *rules.forcedBoard != board {
+		return errors.New("you must play in the sub-board chosen by opponent")
+	}
+
+	if rules.cells[pos.X][pos.Y] != empty {
+		return errors.New("cell is not empty")
+	}
+
+	return nil
+}
+
+func (rules *Rules) ApplyMove(playerId int, move gameRules.Move) (gameRules.MoveResult, error) {
+	err := rules.ValidateMove(playerId, move)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pos := move.(game.Pos)
+	board := boardOf(pos)
+
+	rules.cells[pos.X][pos.Y] = playerId
+	rules.subBoards[board.X][board.Y] = rules.checkSubBoard(board)
+	rules.winState = rules.checkMetaBoard()
+
+	next := game.Pos{X: pos.X % Size, Y: pos.Y % Size}
+	if rules.subBoards[next.X][next.Y] == winState.Values.None {
+		rules.forcedBoard = &next
+	} else {
+		rules.forcedBoard = nil
+	}
+
+	rules.currentPlayer = 1 - playerId
+	rules.moveNumber++
+
+	return MoveResult{
+		Pos: pos,
+		SubBoard: rules.describeSubBoard(board),
+		LegalBoards: rules.GetLegalBoards(),
+	}, nil
+}
+
+// GetLegalBoards returns sub-boards where current player can play.
+func (rules *Rules) GetLegalBoards() []game.Pos {
+	legal := make([]game.Pos, 0, BoardSize)
+
+	if rules.winState != winState.Values.None {
+		return legal
+	}
+
+	if rules.forcedBoard != nil {
+		return append(legal, *rules.forcedBoard)
+	}
+
+	for x := range Size {
+		for y := range Size {
+			if rules.subBoards[x][y] == winState.Values.None {
+				legal = append(legal, game.Pos{X: x, Y: y})
+			}
+		}
+	}
+
+	return legal
+}
+
+func boardOf(pos game.Pos) game.Pos {
+	return game.Pos{X: pos.X / Size, Y: pos.Y / Size}
+}
+
+func (rules *Rules) checkSubBoard(board game.Pos) winState.WinState {
+	cell := func(x, y int) int {
+		return rules.cells[board.X * Size + x][board.Y * Size + y]
+	}
+
+	winner := lineWinner(cell)
+	if winner != empty {
+		return rules.createWin(winner)
+	}
+
+	for x := range Size {
+		for y := range Size {
+			if cell(x, y) == empty {
+				return winState.Values.None
+			}
+		}
+	}
+
+	return winState.Values.Draw
+}
+
+func (rules *Rules) checkMetaBoard() winState.WinState {
+	owner := func(x, y int) int {
+		ws := rules.subBoards[x][y]
+
+		if winState.IsWin(ws) {
+			return ws.GetPlayer().Id
+		}
+		return empty
+	}
+
+	winner := lineWinner(owner)
+	if winner != empty {
+		return rules.createWin(winner)
+	}
+
+	for x := range Size {
+		for y := range Size {
+			if rules.subBoards[x][y] == winState.Values.None {
+				return winState.Values.None
+			}
+		}
+	}
+
+	return winState.Values.Draw
+}
+
+// Lines of 3x3 grid as {x, y} cells.
+var lines = [8][Size][2]int{
+	{{0, 0}, {0, 1}, {0, 2}},
+	{{1, 0}, {1, 1}, {1, 2}},
+	{{2, 0}, {2, 1}, {2, 2}},
+	{{0, 0}, {1, 0}, {2, 0}},
+	{{0, 1}, {1, 1}, {2, 1}},
+	{{0, 2}, {1, 2}, {2, 2}},
+	{{0, 0}, {1, 1}, {2, 2}},
+	{{2, 0}, {1, 1}, {0, 2}},
+}
+
+// lineWinner returns owner of a full line on 3x3 grid or empty.
+func lineWinner(get func(x, y int) int) int {
+	for _, line := range lines {
+		first := get(line[0][0], line[0][1])
+
+		if first != empty && first == get(line[1][0], line[1][1]) && first == get(line[2][0], line[2][1]) {
+			return first
+		}
+	}
+
+	return empty
+}
+
+func (rules *Rules) createWin(playerId int) winState.WinState {
+	return winState.CreateWin(winState.Player{
+		Id: playerId,
+		Char: int(rules.symbols[playerId]),
+	})
+}
+
+func (rules *Rules) describeSubBoard(board game.Pos) SubBoard {
+	ws := rules.subBoards[board.X][board.Y]
+	subBoard := SubBoard{
+		Board: board,
+		Status: winState.Status(ws),
+	}
+
+	if winState.IsWin(ws) {
+		winner := ws.GetPlayer().Id
+		subBoard.Winner = &winner
+	}
+
+	return subBoard
+}
+
+func (rules *Rules) GetCurrentPlayer() int {
+	return rules.currentPlayer
+}
+
+func (rules *Rules) GetOutcome() winState.WinState {
+	return rules.winState
+}
+
+func (rules *Rules) GetSubBoardOutcome(board game.Pos) winState.WinState {
+	return rules.subBoards[board.X][board.Y]
+}
+
+func (rules *Rules) GetState() any {
+	board := make([][]rune, BoardSize)
+
+	for x := range BoardSize {
+		board[x] = make([]rune, BoardSize)
+
+		for y := range BoardSize {
+			board[x][y] = ' '
+			if id := rules.cells[x][y]; id != empty {
+				board[x][y] = rules.symbols[id]
+			}
+		}
+	}
+
+	subBoards := make([]SubBoard, 0, BoardSize)
+	for x := range Size {
+		for y := range Size {
+			subBoards = append(subBoards, rules.describeSubBoard(game.Pos{X: x, Y: y}))
+		}
+	}
+
+	return State{
+		Board: board,
+		SubBoards: subBoards,
+		LegalBoards: rules.GetLegalBoards(),
+		CurrentPlayer: rules.currentPlayer,
+	}
+}
+
+func (rules *Rules) GetPlayerSymbol(playerId int) rune {
+	assert.Assert(playerId == 0 || playerId == 1, "player id must be 0 or 1", "player id", playerId)
+
+	return rules.symbols[playerId]
+}
+
+// SwapSymbols exchanges symbols of the players, cells hold player ids so it can be done anytime.
+func (rules *Rules) SwapSymbols() {
+	rules.symbols[0], rules.symbols[1] = rules.symbols[1], rules.symbols[0]
+}
+
+func (rules *Rules) GetMoveNumber() int {
+	return rules.moveNumber
+}
+
+func (rules *Rules) Clone() gameRules.Searchable {
+	clone := *rules
+
+	if rules.forcedBoard != nil {
+		forced := *rules.forcedBoard
+		clone.forcedBoard = &forced
+	}
+
+	return &clone
+}
+
+func (rules *Rules) GetLegalMoves() []gameRules.Move {
+	moves := []gameRules.Move{}
+
+	for _, board := range rules.GetLegalBoards() {
+		for x := board.X * Size; x < (board.X + 1) * Size; x++ {
+			for y := board.Y * Size; y < (board.Y + 1) * Size; y++ {
+				if rules.cells[x][y] == empty {
+					moves = append(moves, game.Pos{X: x, Y: y})
+				}
+			}
+		}
+	}
+
+	return moves
+}
@@ -0,0 +1,80 @@
+package ultimate
+
+import (
+	"GridPlay/game"
+	"GridPlay/game/winState"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createTestRules(t *testing.T) *Rules {
+	rules, err := CreateRules(nil)
+	require.NoError(t, err)
+
+	return rules.(*Rules)
+}
+
+func TestUltimateForcedBoard(t *testing.T) {
+	rules := createTestRules(t)
+
+	result, err := rules.ApplyMove(0, game.Pos{X: 4, Y: 4})
+	require.NoError(t, err)
+	require.Equal(t, []game.Pos{{X: 1, Y: 1}}, result.(MoveResult).LegalBoards)
+
+	require.Error(t, rules.ValidateMove(1, game.Pos{X: 0, Y: 0}))
+	require.Error(t, rules.ValidateMove(1, game.Pos{X: 4, Y: 4}))
+
+	result, err = rules.ApplyMove(1, game.Pos{X: 3, Y: 3})
+	require.NoError(t, err)
+	require.Equal(t, []game.Pos{{X: 0, Y: 0}}, result.(MoveResult).LegalBoards)
+	require.Equal(t, "none", result.(MoveResult).SubBoard.Status)
+}
+
+func TestUltimateFinishedBoardFreesChoice(t *testing.T) {
+	rules := createTestRules(t)
+	rules.subBoards[1][1] = winState.Values.Draw
+
+	result, err := rules.ApplyMove(0, game.Pos{X: 1, Y: 1})
+	require.NoError(t, err)
+	require.Len(t, result.(MoveResult).LegalBoards, 8)
+	require.NotContains(t, result.(MoveResult).LegalBoards, game.Pos{X: 1, Y: 1})
+
+	require.Error(t, rules.ValidateMove(1, game.Pos{X: 4, Y: 4}))
+	require.NoError(t, rules.ValidateMove(1, game.Pos{X: 8, Y: 8}))
+}
+
+func TestUltimateSubBoardWin(t *testing.T) {
+	rules := createTestRules(t)
+	rules.cells[0][0] = 0
+	rules.cells[1][1] = 0
+
+	result, err := rules.ApplyMove(0, game.Pos{X: 2, Y: 2})
+	require.NoError(t, err)
+
+	subBoard := result.(MoveResult).SubBoard
+	require.Equal(t, "win", subBoard.Status)
+	require.Equal(t, 0, *subBoard.Winner)
+	require.True(t, winState.IsWin(rules.GetSubBoardOutcome(game.Pos{X: 0, Y: 0})))
+	require.Equal(t, winState.Values.None, rules.GetOutcome())
+
+	require.Equal(t, []game.Pos{{X: 2, Y: 2}}, result.(MoveResult).LegalBoards)
+}
+
+func TestUltimateMetaWin(t *testing.T) {
+	rules := createTestRules(t)
+	rules.subBoards[0][0] = rules.createWin(0)
+	rules.subBoards[0][1] = rules.createWin(0)
+	rules.subBoards[1][0] = rules.createWin(1)
+	rules.cells[0][6] = 0
+	rules.cells[1][7] = 0
+
+	_, err := rules.ApplyMove(0, game.Pos{X: 2, Y: 8})
+	require.NoError(t, err)
+
+	outcome := rules.GetOutcome()
+	require.True(t, winState.IsWin(outcome))
+	require.Equal(t, 0, outcome.GetPlayer().Id)
+	require.Empty(t, rules.GetLegalBoards())
+	require.Error(t, rules.ValidateMove(1, game.Pos{X: 8, Y: 8}))
+}
@@ -1,7 +1,12 @@
-const socket = new WebSocket("ws://192.168.1.185:4000/ws");
+// Query of the page is passed to the server, e.g. index.html?bot=perfect
+// After page reload the match is resumed, the token is kept for the browser tab.
+const resumeToken = sessionStorage.getItem("resumeToken");
+const query = resumeToken ? `?resume=${resumeToken}` : window.location.search;
+const socket = new WebSocket("ws://192.168.1.185:4000/ws" + query);
 GetStatusEl().innerHTML = "Waiting for match...";
 
 socket.onerror=function(event){
+    sessionStorage.removeItem("resumeToken");
     GetStatusEl().innerHTML = "Cannot connect to server.";
     console.log("Connection error: ", event)
 }
@@ -20,9 +25,37 @@ const MatchStarted = 0
 const MoveAns = 1
 const OpponentMove = 2
 const WinEvent = 3
+const NotAllowedErr = 4
+const TakeBackRequest = 5
+const TakeBackAns = 6
+const GameState = 7
+const OpponentConnection = 11
+const DrawOffer = 12
+const DrawDeclined = 13
+const RematchRequest = 14
+const RematchDeclined = 15
+const SeriesEnd = 16
+const PrivateRoomCreated = 17
+const InviteExpired = 18
+const RoomOpened = 19
+const Lobby = 20
 
 // From client
 const Move = 0
+const RequestTakeBack = 1
+const AnswerTakeBack = 2
+const RequestGameState = 3
+const Resign = 4
+const OfferDraw = 5
+const AcceptDraw = 6
+const DeclineDraw = 7
+const RequestRematch = 8
+const AnswerRematch = 9
+const CreatePrivateRoom = 10
+const JoinPrivateRoom = 11
+const OpenRoom = 12
+const JoinRoom = 13
+const SubscribeLobby = 15
 
 var lastMovePos;
 var char;
@@ -43,11 +76,14 @@ socket.onmessage = function(messageJson) {
                 document.dispatchEvent(eventMove);
             } else {
                 console.log(`Move rejected reason: ${messageData.reason}`)
+                // Board may be out of date, redraw it from the server.
+                socket.send(JSON.stringify({type: RequestGameState, data: null}));
             }
             
             break;
         case MatchStarted:
             console.log("Match started");
+            sessionStorage.setItem("resumeToken", messageData.resumeToken);
             const eventMatchStarted = new CustomEvent("eventMatchStarted", {
                 detail: {
                     char: String.fromCharCode(messageData.char),
@@ -59,10 +95,14 @@ socket.onmessage = function(messageJson) {
             break;
         case WinEvent:
             console.log("Game end: ", messageData.status, messageData.cause);
+            sessionStorage.removeItem("resumeToken");
 
             const eventWin = new CustomEvent("eventWin", {
                 detail: {
                     status: messageData.status,
+                    cause: messageData.cause,
+                    series: messageData.series,
+                    rating: messageData.rating,
                 }
             });
             document.dispatchEvent(eventWin);
@@ -77,6 +117,66 @@ socket.onmessage = function(messageJson) {
             });
             document.dispatchEvent(eventOpponentMove);
             break;
+        case TakeBackRequest:
+            const accept = confirm(`Opponent asks to take back ${messageData.moves} move(s).`);
+            socket.send(JSON.stringify({type: AnswerTakeBack, data: {accept: accept}}));
+            break;
+        case TakeBackAns:
+            console.log("Take-back answer: ", messageData.accepted, messageData.reason);
+            if (!messageData.accepted) {
+                GetStatusEl().innerHTML = messageData.reason;
+            }
+            break;
+        case GameState:
+            const eventGameState = new CustomEvent("gameState", {
+                detail: {
+                    state: messageData.state,
+                    yourTurn: messageData.currentPlayer == messageData.seat,
+                    outcome: messageData.outcome,
+                }
+            });
+            document.dispatchEvent(eventGameState);
+            break;
+        case OpponentConnection:
+            if (!messageData.connected) {
+                GetStatusEl().innerHTML = `Opponent disconnected, waiting ${messageData.grace} seconds...`;
+            } else {
+                GetStatusEl().innerHTML = "Opponent is back";
+            }
+            break;
+        case DrawOffer:
+            const acceptDraw = confirm("Opponent offers a draw.");
+            socket.send(JSON.stringify({type: acceptDraw ? AcceptDraw : DeclineDraw, data: null}));
+            break;
+        case RematchRequest:
+            const acceptRematch = confirm("Opponent asks for a rematch.");
+            socket.send(JSON.stringify({type: AnswerRematch, data: {accept: acceptRematch}}));
+            break;
+        case RematchDeclined:
+            GetStatusEl().innerHTML = messageData.reason;
+            break;
+        case SeriesEnd:
+            console.log("Series end: ", messageData.status);
+            GetStatusEl().innerHTML = `Series ${messageData.status}, ${messageData.series.score} : ${messageData.series.opponentScore}`;
+            break;
+        case DrawDeclined:
+            GetStatusEl().innerHTML = messageData.reason;
+            break;
+        case PrivateRoomCreated:
+            GetStatusEl().innerHTML = `Invite code: ${messageData.code}, valid for ${messageData.expiresIn} seconds`;
+            break;
+        case RoomOpened:
+            GetStatusEl().innerHTML = `Room is open in the lobby, invite code: ${messageData.code}`;
+            break;
+        case Lobby:
+            ShowLobby(messageData.rooms);
+            break;
+        case InviteExpired:
+            GetStatusEl().innerHTML = `Invite code ${messageData.code} has expired, waiting for an opponent...`;
+            break;
+        case NotAllowedErr:
+            console.log("Not allowed: ", messageData.reason);
+            break;
     }
     /*} catch {
         console.error("cannot parse message from server");
@@ -125,6 +225,75 @@ document.addEventListener("opponentMove", e => {
     GetStatusEl().innerHTML = "Your turn";
 });
 
+document.addEventListener("gameState", e => {
+    const state = e.detail.state;
+
+    for(let x = 0; x < 3; x++) {
+        for(let y = 0; y < 3; y++) {
+            GetCell(new Pos(x, y)).innerHTML = String.fromCharCode(state.board[x][y]);
+        }
+    }
+
+    if (e.detail.outcome.status != "none") {
+        return;
+    }
+
+    GetStatusEl().innerHTML = e.detail.yourTurn ? "Your turn" : "Opponent turn";
+});
+
+function RequestTakeBackClick() {
+    socket.send(JSON.stringify({type: RequestTakeBack, data: null}));
+}
+
+function OfferDrawClick() {
+    socket.send(JSON.stringify({type: OfferDraw, data: null}));
+}
+
+function RequestRematchClick() {
+    socket.send(JSON.stringify({type: RequestRematch, data: null}));
+}
+
+function CreatePrivateRoomClick() {
+    socket.send(JSON.stringify({type: CreatePrivateRoom, data: null}));
+}
+
+function JoinPrivateRoomClick() {
+    const code = prompt("Invite code:");
+    if (code) {
+        socket.send(JSON.stringify({type: JoinPrivateRoom, data: {code: code}}));
+    }
+}
+
+function OpenRoomClick() {
+    socket.send(JSON.stringify({type: OpenRoom, data: null}));
+}
+
+function LobbyClick() {
+    socket.send(JSON.stringify({type: SubscribeLobby, data: {subscribe: true}}));
+}
+
+function ShowLobby(rooms) {
+    const lobby = document.querySelector(".lobby");
+    lobby.innerHTML = "";
+
+    for (const room of rooms) {
+        const button = document.createElement("button");
+        const action = room.open ? "Play" : `Watch (${room.spectators})`;
+        button.innerText = `${room.game}, ${room.host}: ${action}`;
+        button.addEventListener("click", () => {
+            socket.send(JSON.stringify({type: JoinRoom, data: {id: room.id}}));
+            lobby.innerHTML = "";
+        });
+        lobby.appendChild(button);
+    }
+}
+
+function ResignClick() {
+    if (confirm("Do you want to resign?")) {
+        socket.send(JSON.stringify({type: Resign, data: null}));
+    }
+}
+
 document.addEventListener("eventWin", e => {
     console.log("Event win: status: ", e.detail.status)
     let status;
@@ -137,6 +306,19 @@ document.addEventListener("eventWin", e => {
         status = "Tie!";
     }*/
     status = e.detail.status;
+    if (e.detail.cause != "") {
+        status += ` (${e.detail.cause})`;
+    }
+    const series = e.detail.series;
+    status += `, series ${series.score} : ${series.opponentScore}`;
+    if (series.bestOf > 1) {
+        status += ` (best of ${series.bestOf})`;
+    }
+    const rating = e.detail.rating;
+    if (rating) {
+        const change = rating.after - rating.before;
+        status += `, rating ${rating.after} (${change >= 0 ? "+" : ""}${change})`;
+    }
     console.log(status);
     GetStatusEl().innerHTML = status;
     gameEnded = true;